@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/chihqiang/mpgrm/factory"
+	"github.com/chihqiang/mpgrm/flags"
+	"github.com/chihqiang/mpgrm/pkg/logx"
+	"github.com/urfave/cli/v3"
+	"time"
+)
+
+// AccessCommand defines the CLI command to migrate collaborators and teams.
+func AccessCommand() *cli.Command {
+	return &cli.Command{
+		UseShortOptionHandling: true,
+		Name:                   "access",
+		Usage:                  "Give people the same access on the target as on the source",
+		Commands: []*cli.Command{
+			{
+				Name:  "sync",
+				Flags: flags.FormTargetAccessSync(),
+				Usage: "Sync collaborators of a repo or organization, and the teams of an organization, using a user-mapping file",
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					start := time.Now()
+					logx.Info("Starting access sync...")
+					target, err := factory.NewDoubleRepo(ctx, cmd)
+					if err != nil {
+						return fmt.Errorf("failed to initialize target repo: %w", err)
+					}
+					if err := target.AccessSync(); err != nil {
+						return fmt.Errorf("access sync failed: %w", err)
+					}
+					logx.Info("Access sync completed in %s", time.Since(start))
+					return nil
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/chihqiang/mpgrm/factory"
+	"github.com/chihqiang/mpgrm/flags"
+	"github.com/chihqiang/mpgrm/pkg/logx"
+	"github.com/urfave/cli/v3"
+	"time"
+)
+
+// HooksCommand defines the CLI command to manage webhooks.
+func HooksCommand() *cli.Command {
+	return &cli.Command{
+		UseShortOptionHandling: true,
+		Name:                   "hooks",
+		Usage:                  "Point every webhook at the right place in one go",
+		Commands: []*cli.Command{
+			{
+				Name:  "sync",
+				Flags: flags.FormTargetHookSync(),
+				Usage: "Sync webhooks from a source repo or template file to a target repo or every repo of a target organization",
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					start := time.Now()
+					logx.Info("Starting webhook sync...")
+					target, err := factory.NewDoubleRepo(ctx, cmd)
+					if err != nil {
+						return fmt.Errorf("failed to initialize target repo: %w", err)
+					}
+					if err := target.HookSync(); err != nil {
+						return fmt.Errorf("webhook sync failed: %w", err)
+					}
+					logx.Info("Webhook sync completed in %s", time.Since(start))
+					return nil
+				},
+			},
+		},
+	}
+}
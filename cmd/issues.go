@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/chihqiang/mpgrm/factory"
+	"github.com/chihqiang/mpgrm/flags"
+	"github.com/chihqiang/mpgrm/pkg/logx"
+	"github.com/urfave/cli/v3"
+	"time"
+)
+
+// IssuesCommand defines the CLI command to manage issues.
+func IssuesCommand() *cli.Command {
+	return &cli.Command{
+		UseShortOptionHandling: true,
+		Name:                   "issues",
+		Usage:                  "Carry your issue history wherever you go",
+		Commands: []*cli.Command{
+			{
+				Name:  "sync",
+				Flags: flags.FormTargetIssueSync(),
+				Usage: "Sync issues and comments from source repo to target repo",
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					start := time.Now()
+					logx.Info("Starting issue sync...")
+					target, err := factory.NewDoubleRepo(ctx, cmd)
+					if err != nil {
+						return fmt.Errorf("failed to initialize target repo: %w", err)
+					}
+					if err := target.IssueSync(); err != nil {
+						return fmt.Errorf("issue sync failed: %w", err)
+					}
+					logx.Info("Issue sync completed in %s", time.Since(start))
+					return nil
+				},
+			},
+		},
+	}
+}
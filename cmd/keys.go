@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/chihqiang/mpgrm/factory"
+	"github.com/chihqiang/mpgrm/flags"
+	"github.com/chihqiang/mpgrm/pkg/logx"
+	"github.com/urfave/cli/v3"
+	"time"
+)
+
+// KeysCommand defines the CLI command to manage deploy keys.
+func KeysCommand() *cli.Command {
+	return &cli.Command{
+		UseShortOptionHandling: true,
+		Name:                   "keys",
+		Usage:                  "Same deploy keys on every mirror",
+		Commands: []*cli.Command{
+			{
+				Name:  "sync",
+				Flags: flags.FormTargetKeySync(),
+				Usage: "Sync deploy keys from a source repo or key file to a target repo or every matching repo of a target organization",
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					start := time.Now()
+					logx.Info("Starting deploy key sync...")
+					target, err := factory.NewDoubleRepo(ctx, cmd)
+					if err != nil {
+						return fmt.Errorf("failed to initialize target repo: %w", err)
+					}
+					if err := target.DeployKeySync(); err != nil {
+						return fmt.Errorf("deploy key sync failed: %w", err)
+					}
+					logx.Info("Deploy key sync completed in %s", time.Since(start))
+					return nil
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/chihqiang/mpgrm/factory"
+	"github.com/chihqiang/mpgrm/flags"
+	"github.com/chihqiang/mpgrm/pkg/logx"
+	"github.com/urfave/cli/v3"
+	"time"
+)
+
+// LabelsCommand defines the CLI command to manage labels.
+func LabelsCommand() *cli.Command {
+	return &cli.Command{
+		UseShortOptionHandling: true,
+		Name:                   "labels",
+		Usage:                  "One label set, every repo, every platform",
+		Commands: []*cli.Command{
+			{
+				Name:  "sync",
+				Flags: flags.FormTargetRepo(),
+				Usage: "Sync labels from source repo to a target repo or every repo of a target organization",
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					start := time.Now()
+					logx.Info("Starting label sync...")
+					target, err := factory.NewDoubleRepo(ctx, cmd)
+					if err != nil {
+						return fmt.Errorf("failed to initialize target repo: %w", err)
+					}
+					if err := target.LabelSync(); err != nil {
+						return fmt.Errorf("label sync failed: %w", err)
+					}
+					logx.Info("Label sync completed in %s", time.Since(start))
+					return nil
+				},
+			},
+		},
+	}
+}
+
+// MilestonesCommand defines the CLI command to manage milestones.
+func MilestonesCommand() *cli.Command {
+	return &cli.Command{
+		UseShortOptionHandling: true,
+		Name:                   "milestones",
+		Usage:                  "Keep your milestones on schedule everywhere",
+		Commands: []*cli.Command{
+			{
+				Name:  "sync",
+				Flags: flags.FormTargetRepo(),
+				Usage: "Sync milestones from source repo to a target repo or every repo of a target organization",
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					start := time.Now()
+					logx.Info("Starting milestone sync...")
+					target, err := factory.NewDoubleRepo(ctx, cmd)
+					if err != nil {
+						return fmt.Errorf("failed to initialize target repo: %w", err)
+					}
+					if err := target.MilestoneSync(); err != nil {
+						return fmt.Errorf("milestone sync failed: %w", err)
+					}
+					logx.Info("Milestone sync completed in %s", time.Since(start))
+					return nil
+				},
+			},
+		},
+	}
+}
@@ -6,23 +6,54 @@ import (
 	"github.com/chihqiang/mpgrm/cmd"
 	"github.com/chihqiang/mpgrm/flags"
 	"github.com/chihqiang/mpgrm/pkg/logx"
+	"github.com/chihqiang/mpgrm/pkg/metrics"
+	"github.com/chihqiang/mpgrm/pkg/report"
 	"github.com/chihqiang/mpgrm/register"
 	"github.com/joho/godotenv"
 	"github.com/urfave/cli/v3"
 	"os"
 	"runtime"
+	"strings"
+	"time"
 )
 
 var (
 	version  = "main"
 	commands []*cli.Command
+	command  = "none" // the subcommand that ran, e.g. "repo sync", used as a metric label
 )
 
 func init() {
 	commands = append(commands, cmd.PushCommand())
+	commands = append(commands, cmd.SyncCommand())
 	commands = append(commands, cmd.ReleasesCommand())
+	commands = append(commands, cmd.LabelsCommand())
+	commands = append(commands, cmd.MilestonesCommand())
+	commands = append(commands, cmd.IssuesCommand())
+	commands = append(commands, cmd.PullsCommand())
+	commands = append(commands, cmd.HooksCommand())
+	commands = append(commands, cmd.KeysCommand())
+	commands = append(commands, cmd.ProtectionCommand())
+	commands = append(commands, cmd.AccessCommand())
 	commands = append(commands, cmd.RepoCommand())
+	commands = append(commands, cmd.VerifyCommand())
 	commands = append(commands, cmd.CredentialCommand())
+	commands = append(commands, cmd.ServeCommand())
+	trackCommand(commands)
+}
+
+// trackCommand records the full name of the leaf command that runs.
+func trackCommand(commands []*cli.Command) {
+	for _, c := range commands {
+		if len(c.Commands) > 0 {
+			trackCommand(c.Commands)
+			continue
+		}
+		c.Before = func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			command = strings.TrimPrefix(cmd.FullName(), cmd.Root().Name+" ")
+			return ctx, nil
+		}
+	}
 }
 
 func main() {
@@ -40,7 +71,25 @@ func main() {
 		return ctx, nil
 	}
 	app.Commands = commands
-	if err := app.Run(context.Background(), os.Args); err != nil {
+	start := time.Now()
+	err := app.Run(context.Background(), os.Args)
+	status := report.StatusSuccess
+	if err != nil {
+		status = report.StatusFailed
+	}
+	metrics.RunDuration.WithLabelValues(command, status).Observe(time.Since(start).Seconds())
+	metrics.LastRun.WithLabelValues(command, status).SetToCurrentTime()
+	if path := flags.GetReport(app); path != "" {
+		if reportErr := report.Write(path, err); reportErr != nil {
+			logx.Warn("failed to write report %s: %v", path, reportErr)
+		}
+	}
+	if path := flags.GetMetricsFile(app); path != "" {
+		if metricsErr := metrics.WriteFile(path); metricsErr != nil {
+			logx.Warn("failed to write metrics %s: %v", path, metricsErr)
+		}
+	}
+	if err != nil {
 		logx.Error(err.Error())
 		os.Exit(1)
 	}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"context"
+	"github.com/chihqiang/mpgrm/flags"
+	"github.com/chihqiang/mpgrm/pkg/logx"
+	"github.com/chihqiang/mpgrm/pkg/notify"
+	"github.com/chihqiang/mpgrm/pkg/report"
+	"github.com/urfave/cli/v3"
+	"strings"
+	"time"
+)
+
+// withNotify wraps action to send a summary to the --notify channels when it finishes.
+// Notification failures are logged and do not change the command result.
+func withNotify(action cli.ActionFunc) cli.ActionFunc {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		notifiers, err := flags.GetNotifiers(cmd)
+		if err != nil {
+			return err
+		}
+		on, err := flags.GetNotifyOn(cmd)
+		if err != nil {
+			return err
+		}
+		start := time.Now()
+		err = action(ctx, cmd)
+		if len(notifiers) == 0 {
+			return err
+		}
+		command := strings.TrimPrefix(cmd.FullName(), cmd.Root().Name+" ")
+		summary := notify.NewSummary(command, report.Results(), time.Since(start), err)
+		if on == notify.OnFailure && summary.Status == report.StatusSuccess {
+			return err
+		}
+		// Still notify when the command was canceled.
+		notifyCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), time.Minute)
+		defer cancel()
+		for _, n := range notifiers {
+			if notifyErr := n.Notify(notifyCtx, summary); notifyErr != nil {
+				logx.Warn("failed to send %s notification: %v", n.Name(), notifyErr)
+				continue
+			}
+			logx.Info("Sent %s notification", n.Name())
+		}
+		return err
+	}
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/chihqiang/mpgrm/factory"
+	"github.com/chihqiang/mpgrm/flags"
+	"github.com/chihqiang/mpgrm/pkg/logx"
+	"github.com/urfave/cli/v3"
+	"time"
+)
+
+// ProtectionCommand defines the CLI command to manage branch protection rules.
+func ProtectionCommand() *cli.Command {
+	return &cli.Command{
+		UseShortOptionHandling: true,
+		Name:                   "protection",
+		Usage:                  "Keep branch protection consistent across repositories",
+		Commands: []*cli.Command{
+			{
+				Name:  "sync",
+				Flags: flags.FormTargetProtectionSync(),
+				Usage: "Apply branch protection from a source repo or policy file to a target repo or every repo of a target organization",
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					start := time.Now()
+					logx.Info("Starting branch protection sync...")
+					target, err := factory.NewDoubleRepo(ctx, cmd)
+					if err != nil {
+						return fmt.Errorf("failed to initialize target repo: %w", err)
+					}
+					if err := target.ProtectionSync(); err != nil {
+						return fmt.Errorf("branch protection sync failed: %w", err)
+					}
+					logx.Info("Branch protection sync completed in %s", time.Since(start))
+					return nil
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/chihqiang/mpgrm/factory"
+	"github.com/chihqiang/mpgrm/flags"
+	"github.com/chihqiang/mpgrm/pkg/logx"
+	"github.com/urfave/cli/v3"
+	"time"
+)
+
+// PullsCommand defines the CLI command to manage pull requests.
+func PullsCommand() *cli.Command {
+	return &cli.Command{
+		UseShortOptionHandling: true,
+		Name:                   "pulls",
+		Usage:                  "Keep your code review history when you move",
+		Commands: []*cli.Command{
+			{
+				Name:  "sync",
+				Flags: flags.FormTargetPullSync(),
+				Usage: "Sync pull requests and review comments from source repo to target repo",
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					start := time.Now()
+					mode, err := flags.GetPullMode(cmd)
+					if err != nil {
+						return err
+					}
+					logx.Info("Starting pull request sync in %s mode...", mode)
+					target, err := factory.NewDoubleRepo(ctx, cmd)
+					if err != nil {
+						return fmt.Errorf("failed to initialize target repo: %w", err)
+					}
+					if err := target.PullSync(mode); err != nil {
+						return fmt.Errorf("pull request sync failed: %w", err)
+					}
+					logx.Info("Pull request sync completed in %s", time.Since(start))
+					return nil
+				},
+			},
+		},
+	}
+}
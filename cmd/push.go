@@ -2,9 +2,12 @@ package cmd
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"github.com/chihqiang/mpgrm/factory"
 	"github.com/chihqiang/mpgrm/flags"
 	"github.com/chihqiang/mpgrm/pkg/logx"
+	"github.com/chihqiang/mpgrm/pkg/report"
 	"github.com/urfave/cli/v3"
 	"time"
 )
@@ -16,19 +19,34 @@ func PushCommand() *cli.Command {
 		Name:                   "push",
 		Usage:                  "Stress-free push, releases on demand",
 		Flags:                  flags.FormTargetRepoPush(),
-		Action: func(ctx context.Context, cmd *cli.Command) error {
+		Action: withNotify(func(ctx context.Context, cmd *cli.Command) error {
 			git, err := factory.NewCmdDoubleGit(ctx, cmd)
 			if err != nil {
 				return err
 			}
+			targets, err := flags.GetTargetCredentials(cmd)
+			if err != nil {
+				return err
+			}
 			logx.Info("Starting push operation...")
 			start := time.Now()
-			if err := git.Push(); err != nil {
-				return err
+			// The source is fetched once and pushed to every target in turn
+			var errs []error
+			for _, target := range targets {
+				targetStart := time.Now()
+				err := git.PushTo(target)
+				report.Add(cmd.String(flags.FlagsFormRepo), target.DisplayURL(), targetStart, err)
+				if err != nil {
+					logx.Error("push %s: %v", target.DisplayURL(), err)
+					errs = append(errs, fmt.Errorf("push %s: %w", target.DisplayURL(), err))
+				}
+			}
+			if len(errs) > 0 {
+				return errors.Join(errs...)
 			}
 			elapsed := time.Since(start)
 			logx.Info("Git push completed successfully, elapsed time: %s", elapsed)
 			return nil
-		},
+		}),
 	}
 }
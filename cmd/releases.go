@@ -89,7 +89,7 @@ func ReleasesCommand() *cli.Command {
 				Name:  "sync",
 				Flags: flags.FormTargetReleaseSync(),
 				Usage: "Sync releases from source repo to target repo",
-				Action: func(ctx context.Context, cmd *cli.Command) error {
+				Action: withNotify(func(ctx context.Context, cmd *cli.Command) error {
 					start := time.Now()
 					logx.Info("Starting release sync...")
 					target, err := factory.NewDoubleRepo(ctx, cmd)
@@ -104,7 +104,7 @@ func ReleasesCommand() *cli.Command {
 					}
 					logx.Info("Release sync completed in %s", time.Since(start))
 					return nil
-				},
+				}),
 			},
 		},
 	}
@@ -19,7 +19,7 @@ func RepoCommand() *cli.Command {
 			{
 				Name:  "list",
 				Usage: "List all available repositories",
-				Flags: flags.FormFlags(),
+				Flags: flags.FormRecursive(),
 				Action: func(ctx context.Context, cmd *cli.Command) error {
 					start := time.Now()
 					repo, err := factory.NewRepo(ctx, cmd)
@@ -37,7 +37,7 @@ func RepoCommand() *cli.Command {
 			{
 				Name:  "clone",
 				Usage: "Pull down the code and make it yours",
-				Flags: flags.FormFlags(),
+				Flags: flags.FormRecursive(),
 				Action: func(ctx context.Context, cmd *cli.Command) error {
 					start := time.Now()
 					repo, err := factory.NewRepo(ctx, cmd)
@@ -55,8 +55,8 @@ func RepoCommand() *cli.Command {
 			{
 				Name:  "sync",
 				Usage: "Keep your org or personal repos marching in step",
-				Flags: flags.FormTargetRepo(),
-				Action: func(ctx context.Context, cmd *cli.Command) error {
+				Flags: flags.FormTargetRepoSync(),
+				Action: withNotify(func(ctx context.Context, cmd *cli.Command) error {
 					start := time.Now()
 					repo, err := factory.NewRepo(ctx, cmd)
 					if err != nil {
@@ -68,7 +68,7 @@ func RepoCommand() *cli.Command {
 					}
 					logx.Info("RepoSync completed successfully (elapsed: %s)", time.Since(start))
 					return nil
-				},
+				}),
 			},
 		},
 	}
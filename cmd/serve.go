@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/chihqiang/mpgrm/flags"
+	"github.com/chihqiang/mpgrm/pkg/logx"
+	"github.com/chihqiang/mpgrm/pkg/server"
+	"github.com/urfave/cli/v3"
+	"os"
+	"os/signal"
+	"path"
+	"syscall"
+)
+
+// ServeCommand defines the CLI command to run scheduled sync jobs as a daemon.
+func ServeCommand() *cli.Command {
+	return &cli.Command{
+		UseShortOptionHandling: true,
+		Name:                   "serve",
+		Usage:                  "Keep mirrors fresh on a schedule as a long-running daemon",
+		Flags:                  flags.ServeFlags(),
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			config, err := server.LoadConfig(flags.GetServeConfig(cmd))
+			if err != nil {
+				return err
+			}
+			if listen := flags.GetListen(cmd); listen != "" {
+				config.Listen = listen
+			}
+			executable, err := os.Executable()
+			if err != nil {
+				return fmt.Errorf("failed to locate mpgrm executable: %w", err)
+			}
+			workspace := flags.GetWorkspace(cmd)
+			srv, err := server.New(config, server.Options{
+				Executable: executable,
+				GlobalArgs: []string{
+					"--" + flags.FlagsPlatforms, cmd.String(flags.FlagsPlatforms),
+					"--" + flags.FlagsEnvFile, cmd.String(flags.FlagsEnvFile),
+				},
+				// Every job gets its own directory below the daemon's workspace so clones stay warm
+				// between its runs while jobs running at the same time never touch the same clone.
+				Workspace: path.Join(workspace, "jobs"),
+				LogDir:    path.Join(workspace, "logs"),
+			})
+			if err != nil {
+				return err
+			}
+			ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			logx.Info("Starting mpgrm daemon with %d jobs...", len(config.Jobs))
+			return srv.Run(ctx)
+		},
+	}
+}
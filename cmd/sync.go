@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"context"
+	"github.com/chihqiang/mpgrm/factory"
+	"github.com/chihqiang/mpgrm/flags"
+	"github.com/chihqiang/mpgrm/pkg/report"
+	"github.com/urfave/cli/v3"
+	"time"
+)
+
+// SyncCommand defines the CLI command to fast-forward two repositories without force-pushing.
+func SyncCommand() *cli.Command {
+	return &cli.Command{
+		UseShortOptionHandling: true,
+		Name:                   "sync",
+		Usage:                  "Fast-forward only sync, never overwrite: report diverged branches instead of forcing them",
+		Flags:                  flags.FormTargetSync(),
+		Action: withNotify(func(ctx context.Context, cmd *cli.Command) error {
+			git, err := factory.NewCmdDoubleGit(ctx, cmd)
+			if err != nil {
+				return err
+			}
+			start := time.Now()
+			_, err = git.Sync(flags.GetBidirectional(cmd))
+			report.Add(cmd.String(flags.FlagsFormRepo), cmd.String(flags.FlagsTargetRepo), start, err)
+			return err
+		}),
+	}
+}
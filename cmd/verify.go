@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/chihqiang/mpgrm/factory"
+	"github.com/chihqiang/mpgrm/flags"
+	"github.com/chihqiang/mpgrm/pkg/logx"
+	"github.com/urfave/cli/v3"
+	"time"
+)
+
+// VerifyCommand defines the CLI command to detect drift between a source and its mirror.
+func VerifyCommand() *cli.Command {
+	return &cli.Command{
+		UseShortOptionHandling: true,
+		Name:                   "verify",
+		Usage:                  "Prove your mirrors are faithful: compare refs and releases without transferring anything",
+		Flags:                  flags.FormTargetVerify(),
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			start := time.Now()
+			format, err := flags.GetFormat(cmd)
+			if err != nil {
+				return err
+			}
+			repo, err := factory.NewRepo(ctx, cmd)
+			if err != nil {
+				return err
+			}
+			result, err := repo.Verify(flags.GetRefsOnly(cmd))
+			if err != nil {
+				return fmt.Errorf("verify failed: %w", err)
+			}
+			if err := result.Write(cmd.Root().Writer, format); err != nil {
+				return err
+			}
+			logx.Info("Verify completed in %s", time.Since(start))
+			if result.HasDrift() {
+				return fmt.Errorf("drift detected: %d of %d repositories drifted, %d could not be compared", result.Drifted, result.Repos, result.Failed)
+			}
+			return nil
+		},
+	}
+}
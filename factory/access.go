@@ -0,0 +1,242 @@
+package factory
+
+import (
+	"context"
+	"fmt"
+	"github.com/chihqiang/mpgrm/flags"
+	"github.com/chihqiang/mpgrm/pkg/logx"
+	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"github.com/chihqiang/mpgrm/pkg/report"
+	"github.com/chihqiang/mpgrm/pkg/x"
+	"path"
+	"strings"
+	"time"
+)
+
+// AccessSync recreates repository access on the target. Collaborators of every source repository
+// are added to the target repository of the same name, and when both URLs are organizations the
+// teams of the source organization are recreated with their members and repositories.
+// Source users are translated with the user-mapping file (--user-map); users that cannot be mapped are skipped.
+// Returns:
+//   - error: any error encountered while loading the mapping or listing repositories.
+func (t *DoubleRepo) AccessSync() error {
+	start := time.Now()
+	mapping, err := flags.GetUserMapping(t.cmd)
+	if err != nil {
+		return err
+	}
+	pairs, err := t.accessPairs()
+	if err != nil {
+		return err
+	}
+	var teams, members, collaborators int
+	var failed []string
+	if t.isOrgURL() {
+		teamStart := time.Now()
+		teams, members, err = t.syncTeams(mapping, pairs)
+		if err != nil {
+			logx.Warn("failed to sync teams: %v", err)
+			failed = append(failed, "teams")
+		}
+		report.Add(t.repoURL.Redacted(), t.targetURL.Redacted(), teamStart, err)
+	}
+	source, sourceOK := t.platform.(platforms.IPlatformCollaborators)
+	target, targetOK := t.targetPlatform.(platforms.IPlatformCollaborators)
+	if !sourceOK || !targetOK {
+		logx.Warn("Skipping collaborators: platform does not support collaborators")
+	} else {
+		for i, pair := range pairs {
+			logx.Info("Processing repository %s -> %s (%d/%d)", pair[0], pair[1], i+1, len(pairs))
+			repoStart := time.Now()
+			added, err := syncCollaborators(t.ctx, source, target, pair[0], pair[1], mapping)
+			collaborators += added
+			if err != nil {
+				logx.Warn("failed to sync collaborators of %s: %v", pair[1], err)
+				failed = append(failed, pair[1])
+			}
+			report.Add(pair[0], pair[1], repoStart, err)
+		}
+	}
+
+	elapsed := time.Since(start)
+	if len(failed) > 0 {
+		logx.Warn("Access sync completed: %d teams created, %d team members added, %d collaborators added, %d failed (%v), total elapsed: %s", teams, members, collaborators, len(failed), failed, elapsed)
+	} else {
+		logx.Info("Access sync completed: %d teams created, %d team members added, %d collaborators added, total %d repositories, total elapsed: %s", teams, members, collaborators, len(pairs), elapsed)
+	}
+	return nil
+}
+
+// isOrgURL reports whether both the source and the target URL point to an organization.
+func (t *DoubleRepo) isOrgURL() bool {
+	if !strings.HasSuffix(t.repoURL.Path, "/") || !strings.HasSuffix(t.targetURL.Path, "/") {
+		return false
+	}
+	sourceOrg, _ := x.RepoURLParseOrgName(t.repoURL)
+	targetOrg, _ := x.RepoURLParseOrgName(t.targetURL)
+	return sourceOrg != "" && targetOrg != ""
+}
+
+// accessPairs returns the [source, target] full names whose access should be synced.
+// An organization source is matched to the target repositories by repository name,
+// a single source repository is applied to every target repository.
+func (t *DoubleRepo) accessPairs() ([][2]string, error) {
+	targets, err := t.targetFullNames()
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(t.repoURL.Path, "/") {
+		sourceFullName, err := t.credential.GetFullName()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get source full name: %w", err)
+		}
+		pairs := make([][2]string, 0, len(targets))
+		for _, target := range targets {
+			pairs = append(pairs, [2]string{sourceFullName, target})
+		}
+		return pairs, nil
+	}
+	sourceOrg, err := x.RepoURLParseOrgName(t.repoURL)
+	if err != nil {
+		return nil, err
+	}
+	var repos []*platforms.RepoInfo
+	if sourceOrg != "" {
+		repos, err = t.platform.ListOrgRepo(t.ctx, sourceOrg)
+	} else {
+		repos, err = t.platform.ListUserRepo(t.ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list source repositories: %w", err)
+	}
+	targetByName := make(map[string]string, len(targets))
+	for _, target := range targets {
+		targetByName[strings.ToLower(path.Base(target))] = target
+	}
+	var pairs [][2]string
+	for _, repo := range repos {
+		target, ok := targetByName[strings.ToLower(path.Base(repo.FullName))]
+		if !ok {
+			logx.Warn("Skipping %s: no target repository with the same name", repo.FullName)
+			continue
+		}
+		pairs = append(pairs, [2]string{repo.FullName, target})
+	}
+	return pairs, nil
+}
+
+// syncTeams recreates the teams of the source organization in the target organization and returns
+// how many teams were created and members added. Team repositories are limited to the synced pairs.
+func (t *DoubleRepo) syncTeams(mapping *platforms.UserMapping, pairs [][2]string) (int, int, error) {
+	source, sourceOK := t.platform.(platforms.IPlatformTeams)
+	target, targetOK := t.targetPlatform.(platforms.IPlatformTeams)
+	if !sourceOK || !targetOK {
+		logx.Warn("Skipping teams: platform does not support organization teams")
+		return 0, 0, nil
+	}
+	sourceOrg, _ := x.RepoURLParseOrgName(t.repoURL)
+	targetOrg, _ := x.RepoURLParseOrgName(t.targetURL)
+	sourceTeams, err := source.ListTeams(t.ctx, sourceOrg)
+	if err != nil {
+		return 0, 0, fmt.Errorf("list source teams: %w", err)
+	}
+	targetTeams, err := target.ListTeams(t.ctx, targetOrg)
+	if err != nil {
+		return 0, 0, fmt.Errorf("list target teams: %w", err)
+	}
+	existing := make(map[string]*platforms.TeamInfo, len(targetTeams))
+	for _, team := range targetTeams {
+		existing[strings.ToLower(team.Name)] = team
+	}
+	repoNames := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		repoNames[strings.ToLower(path.Base(pair[0]))] = path.Base(pair[1])
+	}
+
+	var created, added int
+	for _, team := range sourceTeams {
+		targetTeam, ok := existing[strings.ToLower(team.Name)]
+		if !ok {
+			targetTeam, err = target.CreateTeam(t.ctx, targetOrg, team)
+			if err != nil {
+				logx.Warn("failed to create team %s: %v", team.Name, err)
+				continue
+			}
+			created++
+			logx.Info("Created team %s in %s", team.Name, targetOrg)
+		}
+		for _, username := range team.Members {
+			targetUser, ok := mapping.Map(username)
+			if !ok {
+				logx.Warn("Skipping member %s of team %s: no user mapping", username, team.Name)
+				continue
+			}
+			if containsFold(targetTeam.Members, targetUser) {
+				continue
+			}
+			if err := target.AddTeamMember(t.ctx, targetOrg, targetTeam, targetUser); err != nil {
+				logx.Warn("failed to add %s to team %s: %v", targetUser, team.Name, err)
+				continue
+			}
+			added++
+		}
+		for _, repo := range team.Repos {
+			targetRepo, ok := repoNames[strings.ToLower(repo)]
+			if !ok || containsFold(targetTeam.Repos, targetRepo) {
+				continue
+			}
+			if err := target.AddTeamRepo(t.ctx, targetOrg, targetTeam, targetRepo); err != nil {
+				logx.Warn("failed to add repository %s to team %s: %v", targetRepo, team.Name, err)
+			}
+		}
+	}
+	return created, added, nil
+}
+
+// syncCollaborators adds the mapped collaborators of the source repository to the target repository
+// and returns how many were added or upgraded. Existing target permissions are never lowered.
+func syncCollaborators(ctx context.Context, source, target platforms.IPlatformCollaborators, sourceFullName, targetFullName string, mapping *platforms.UserMapping) (int, error) {
+	sourceCollaborators, err := source.ListCollaborators(ctx, sourceFullName)
+	if err != nil {
+		return 0, fmt.Errorf("list source collaborators: %w", err)
+	}
+	targetCollaborators, err := target.ListCollaborators(ctx, targetFullName)
+	if err != nil {
+		return 0, fmt.Errorf("list target collaborators: %w", err)
+	}
+	existing := make(map[string]string, len(targetCollaborators))
+	for _, collaborator := range targetCollaborators {
+		existing[strings.ToLower(collaborator.Username)] = collaborator.Permission
+	}
+	var added int
+	for _, collaborator := range sourceCollaborators {
+		targetUser, ok := mapping.Map(collaborator.Username)
+		if !ok {
+			logx.Warn("Skipping collaborator %s of %s: no user mapping", collaborator.Username, sourceFullName)
+			continue
+		}
+		if permission, ok := existing[strings.ToLower(targetUser)]; ok && platforms.ComparePermission(permission, collaborator.Permission) >= 0 {
+			continue
+		}
+		if err := target.AddCollaborator(ctx, targetFullName, &platforms.CollaboratorInfo{
+			Username:   targetUser,
+			Permission: collaborator.Permission,
+		}); err != nil {
+			logx.Warn("failed to add collaborator %s to %s: %v", targetUser, targetFullName, err)
+			continue
+		}
+		logx.Info("Granted %s %s access to %s", targetUser, collaborator.Permission, targetFullName)
+		added++
+	}
+	return added, nil
+}
+
+// containsFold reports whether names contains name, ignoring case.
+func containsFold(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
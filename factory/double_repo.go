@@ -7,7 +7,11 @@ import (
 	"github.com/chihqiang/mpgrm/pkg/credential"
 	"github.com/chihqiang/mpgrm/pkg/logx"
 	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"github.com/chihqiang/mpgrm/pkg/report"
+	"github.com/chihqiang/mpgrm/pkg/x"
 	"github.com/urfave/cli/v3"
+	"net/url"
+	"strings"
 	"time"
 )
 
@@ -17,9 +21,11 @@ type DoubleRepo struct {
 	ctx context.Context // Context for controlling cancellation and deadlines
 	cmd *cli.Command    // CLI command instance
 
+	repoURL    *url.URL               // Source repository or organization URL
 	platform   platforms.IPlatform    // Source repository platform interface (GitHub, Gitee, Gitea, etc.)
 	credential *credential.Credential // Source repository authentication credential
 
+	targetURL        *url.URL               // Target repository or organization URL
 	targetPlatform   platforms.IPlatform    // Target repository platform interface
 	targetCredential *credential.Credential // Target repository authentication credential
 }
@@ -29,24 +35,29 @@ type DoubleRepo struct {
 func NewDoubleRepo(ctx context.Context, cmd *cli.Command) (*DoubleRepo, error) {
 	rt := &DoubleRepo{ctx: ctx, cmd: cmd}
 
-	// Get source repository URL and credential
-	repoURL, cred, err := flags.GetFormCredential(cmd, true)
-	if err != nil {
-		return rt, err
-	}
-	rt.credential = cred
+	// The source is optional for commands that can also read from a template file
+	if cmd.String(flags.FlagsFormRepo) != "" {
+		// Get source repository URL and credential
+		repoURL, cred, err := flags.GetFormCredential(cmd, true)
+		if err != nil {
+			return rt, err
+		}
+		rt.repoURL = repoURL
+		rt.credential = cred
 
-	// Get source platform interface
-	platform, err := platforms.GetPlatform(repoURL, cred)
-	if err != nil {
-		return rt, err
+		// Get source platform interface
+		platform, err := platforms.GetPlatform(repoURL, cred)
+		if err != nil {
+			return rt, err
+		}
+		rt.platform = platform
 	}
-	rt.platform = platform
 	// Get target repository URL and credential
 	targetRepoURL, targetCred, err := flags.GetTargetCredential(cmd)
 	if err != nil {
 		return rt, err
 	}
+	rt.targetURL = targetRepoURL
 	rt.targetCredential = targetCred
 
 	// Get target platform interface
@@ -59,8 +70,72 @@ func NewDoubleRepo(ctx context.Context, cmd *cli.Command) (*DoubleRepo, error) {
 	return rt, nil
 }
 
-// ReleaseSync synchronizes releases from the source repository to the target repository.
-// It can optionally filter by specific tags provided in the `tags` slice.
+// targetFullNames returns the target repositories of the operation.
+// A target URL ending with "/" selects every repository of that organization (or of the user for a root URL),
+// any other target URL selects the single repository it points to.
+func (t *DoubleRepo) targetFullNames() ([]string, error) {
+	if !strings.HasSuffix(t.targetURL.Path, "/") {
+		fullName, err := t.targetCredential.GetFullName()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get target full name: %w", err)
+		}
+		return []string{fullName}, nil
+	}
+	orgName, err := x.RepoURLParseOrgName(t.targetURL)
+	if err != nil {
+		return nil, err
+	}
+	var repos []*platforms.RepoInfo
+	if orgName != "" {
+		repos, err = t.targetPlatform.ListOrgRepo(t.ctx, orgName)
+	} else {
+		repos, err = t.targetPlatform.ListUserRepo(t.ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list target repositories: %w", err)
+	}
+	fullNames := make([]string, 0, len(repos))
+	for _, repo := range repos {
+		fullNames = append(fullNames, repo.FullName)
+	}
+	return fullNames, nil
+}
+
+// releaseTarget is one --target-repo of a release sync and the tags that failed on it.
+type releaseTarget struct {
+	credential *credential.Credential
+	platform   platforms.IPlatform
+	fullName   string
+	failedTags []string
+}
+
+// releaseTargets resolves every --target-repo of the command.
+func (t *DoubleRepo) releaseTargets() ([]*releaseTarget, error) {
+	creds, err := flags.GetTargetCredentials(t.cmd)
+	if err != nil {
+		return nil, err
+	}
+	targets := make([]*releaseTarget, 0, len(creds))
+	for _, cred := range creds {
+		targetURL, err := url.Parse(cred.CloneURL)
+		if err != nil {
+			return nil, err
+		}
+		platform, err := platforms.GetPlatform(targetURL, cred)
+		if err != nil {
+			return nil, err
+		}
+		fullName, err := cred.GetFullName()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get target full name: %w", err)
+		}
+		targets = append(targets, &releaseTarget{credential: cred, platform: platform, fullName: fullName})
+	}
+	return targets, nil
+}
+
+// ReleaseSync synchronizes releases from the source repository to the target repositories.
+// The assets of every tag are downloaded once and uploaded to each target.
 // Parameters:
 //   - tags: a slice of tag names to be synchronized. If empty, all tags will be considered.
 //
@@ -75,9 +150,15 @@ func (t *DoubleRepo) ReleaseSync(tags []string) error {
 	var successCount, failCount int
 	var failedTags []string
 
-	targetFullName, err := t.targetCredential.GetFullName()
+	targets, err := t.releaseTargets()
 	if err != nil {
-		return fmt.Errorf("failed to get target full name: %w", err)
+		return err
+	}
+	// failAll marks the tag as failed on every target, e.g. when its assets cannot be downloaded
+	failAll := func(tag string) {
+		for _, target := range targets {
+			target.failedTags = append(target.failedTags, tag)
+		}
 	}
 
 	for i, tag := range tags {
@@ -90,6 +171,7 @@ func (t *DoubleRepo) ReleaseSync(tags []string) error {
 			logx.Warn("failed to init repo for tag '%s': %v", tag, err)
 			failCount++
 			failedTags = append(failedTags, tag)
+			failAll(tag)
 			logx.Info("Tag %s completed with errors, elapsed: %s", tag, time.Since(tagStart))
 			continue
 		}
@@ -99,6 +181,7 @@ func (t *DoubleRepo) ReleaseSync(tags []string) error {
 			logx.Warn("failed to download files for tag '%s': %v", tag, err)
 			failCount++
 			failedTags = append(failedTags, tag)
+			failAll(tag)
 			logx.Info("Tag %s completed with errors, elapsed: %s", tag, time.Since(tagStart))
 			continue
 		}
@@ -108,34 +191,22 @@ func (t *DoubleRepo) ReleaseSync(tags []string) error {
 			logx.Warn("no files found for tag '%s'", tag)
 			failCount++
 			failedTags = append(failedTags, tag)
+			failAll(tag)
 			logx.Info("Tag %s completed with errors, elapsed: %s", tag, time.Since(tagStart))
 			continue
 		}
 
-		// 获取目标 Release
-		releaseInfo, err := t.targetPlatform.GetTagReleaseInfo(t.ctx, targetFullName, tag)
-		if err != nil {
-			logx.Info("Release for tag '%s' not found, creating...", tag)
-			releaseInfo, err = t.targetPlatform.CreateRelease(t.ctx, targetFullName, &platforms.ReleaseInfo{
-				TagName: tag,
-			})
-			if err != nil {
-				logx.Warn("failed to create target release for tag '%s': %v", tag, err)
-				failCount++
-				failedTags = append(failedTags, tag)
-				logx.Info("Tag %s completed with errors, elapsed: %s", tag, time.Since(tagStart))
+		tagFailed := false
+		for _, target := range targets {
+			if err := t.uploadRelease(target, tag, files); err != nil {
+				logx.Warn("%v", err)
+				target.failedTags = append(target.failedTags, tag)
+				tagFailed = true
 				continue
 			}
-			logx.Info("Created target release for tag '%s'", tag)
+			logx.Info("Uploaded %d files to release for tag '%s' of %s", len(files), tag, target.credential.DisplayURL())
 		}
-		//删除通名的文件
-		if err := t.targetPlatform.DeleteReleaseAssets(t.ctx, releaseInfo, files); err != nil {
-			logx.Warn("This is just for deleting duplicate files err %s", err)
-		}
-
-		// 上传文件
-		if err := t.targetPlatform.UploadReleaseAsset(t.ctx, releaseInfo, files); err != nil {
-			logx.Warn("failed to upload %d files to release for tag '%s': %v", len(files), tag, err)
+		if tagFailed {
 			failCount++
 			failedTags = append(failedTags, tag)
 			logx.Info("Tag %s completed with errors, elapsed: %s", tag, time.Since(tagStart))
@@ -144,7 +215,15 @@ func (t *DoubleRepo) ReleaseSync(tags []string) error {
 
 		// 成功日志里带耗时
 		successCount++
-		logx.Info("Uploaded %d files to release for tag '%s', elapsed: %s", len(files), tag, time.Since(tagStart))
+		logx.Info("Tag %s completed, elapsed: %s", tag, time.Since(tagStart))
+	}
+
+	for _, target := range targets {
+		var syncErr error
+		if len(target.failedTags) > 0 {
+			syncErr = fmt.Errorf("releases of %d tags failed: %v", len(target.failedTags), target.failedTags)
+		}
+		report.Add(t.credential.DisplayURL(), target.credential.DisplayURL(), start, syncErr)
 	}
 
 	elapsed := time.Since(start)
@@ -155,3 +234,32 @@ func (t *DoubleRepo) ReleaseSync(tags []string) error {
 	}
 	return nil
 }
+
+// uploadRelease uploads the downloaded files of tag to the release of target, creating the release when needed.
+func (t *DoubleRepo) uploadRelease(target *releaseTarget, tag string, files []string) error {
+	// 获取目标 Release
+	releaseInfo, err := target.platform.GetTagReleaseInfo(t.ctx, target.fullName, tag)
+	if err != nil && !platforms.IsNotFound(err) {
+		return fmt.Errorf("failed to get target release for tag '%s' on %s: %w", tag, target.credential.DisplayURL(), err)
+	}
+	if err != nil {
+		logx.Info("Release for tag '%s' not found on %s, creating...", tag, target.credential.DisplayURL())
+		releaseInfo, err = target.platform.CreateRelease(t.ctx, target.fullName, &platforms.ReleaseInfo{
+			TagName: tag,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create target release for tag '%s' on %s: %w", tag, target.credential.DisplayURL(), err)
+		}
+		logx.Info("Created target release for tag '%s'", tag)
+	}
+	//删除通名的文件
+	if err := target.platform.DeleteReleaseAssets(t.ctx, releaseInfo, files); err != nil {
+		logx.Warn("This is just for deleting duplicate files err %s", err)
+	}
+
+	// 上传文件
+	if err := target.platform.UploadReleaseAsset(t.ctx, releaseInfo, files); err != nil {
+		return fmt.Errorf("failed to upload %d files to release for tag '%s' on %s: %w", len(files), tag, target.credential.DisplayURL(), err)
+	}
+	return nil
+}
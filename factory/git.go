@@ -2,12 +2,17 @@ package factory
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/chihqiang/mpgrm/flags"
 	"github.com/chihqiang/mpgrm/pkg/credential"
 	"github.com/chihqiang/mpgrm/pkg/gitx"
 	"github.com/chihqiang/mpgrm/pkg/logx"
+	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"github.com/chihqiang/mpgrm/pkg/x"
 	"github.com/urfave/cli/v3"
+	"net/url"
+	"slices"
 	"time"
 )
 
@@ -16,9 +21,21 @@ type Git struct {
 
 	workspace      string
 	branches, tags []string
+	includeWiki    bool
+	pushMode       string            // 推送模式，见 gitx.PushForce 等常量
+	protected      []string          // 只允许快进的分支
+	atomic         bool              // 原子推送
+	pushOptions    map[string]string // 推送选项（git push -o）
 
 	credential       *credential.Credential
 	targetCredential *credential.Credential
+
+	// 拉取结果，由 Fetch 设置，供推送到多个目标时复用
+	fetched         bool
+	fetchErr        error
+	migrate         *gitx.GitMigrate
+	gitPath         string
+	fetchedBranches []string
 }
 
 // NewDoubleCredentialGit creates a new Git instance using provided source and target credentials.
@@ -26,12 +43,16 @@ type Git struct {
 func NewDoubleCredentialGit(cmd *cli.Command, credential *credential.Credential, targetCredential *credential.Credential) (*Git, error) {
 	// Initialize Git instance with context, workspace, branches, tags, and credentials
 	rt := &Git{
-		ctx:              context.Background(),    // Background context
-		workspace:        flags.GetWorkspace(cmd), // Local workspace directory
-		branches:         flags.GetBranches(cmd),  // Branches to operate on
-		tags:             flags.GetTags(cmd),      // Tags to operate on
-		credential:       credential,              // Source repository credential
-		targetCredential: targetCredential,        // Target repository credential
+		ctx:              context.Background(),      // Background context
+		workspace:        flags.GetWorkspace(cmd),   // Local workspace directory
+		branches:         flags.GetBranches(cmd),    // Branches to operate on
+		tags:             flags.GetTags(cmd),        // Tags to operate on
+		includeWiki:      flags.GetIncludeWiki(cmd), // Mirror the wiki repository as well
+		credential:       credential,                // Source repository credential
+		targetCredential: targetCredential,          // Target repository credential
+	}
+	if err := rt.setPushMode(cmd); err != nil {
+		return nil, err
 	}
 	// Return the initialized Git instance
 	return rt, nil
@@ -39,11 +60,15 @@ func NewDoubleCredentialGit(cmd *cli.Command, credential *credential.Credential,
 func NewCredentialGit(cmd *cli.Command, credential *credential.Credential) (*Git, error) {
 	// Initialize Git instance with context, workspace, branches, tags, and credentials
 	rt := &Git{
-		ctx:        context.Background(),    // Background context
-		workspace:  flags.GetWorkspace(cmd), // Local workspace directory
-		branches:   flags.GetBranches(cmd),  // Branches to operate on
-		tags:       flags.GetTags(cmd),      // Tags to operate on
-		credential: credential,              // Source repository credential
+		ctx:         context.Background(),      // Background context
+		workspace:   flags.GetWorkspace(cmd),   // Local workspace directory
+		branches:    flags.GetBranches(cmd),    // Branches to operate on
+		tags:        flags.GetTags(cmd),        // Tags to operate on
+		includeWiki: flags.GetIncludeWiki(cmd), // Mirror the wiki repository as well
+		credential:  credential,                // Source repository credential
+	}
+	if err := rt.setPushMode(cmd); err != nil {
+		return nil, err
 	}
 	return rt, nil
 }
@@ -53,10 +78,11 @@ func NewCredentialGit(cmd *cli.Command, credential *credential.Credential) (*Git
 func NewCmdDoubleGit(ctx context.Context, cmd *cli.Command) (*Git, error) {
 	// Create a new Git instance with context, workspace, branches, and tags from command flags
 	rt := &Git{
-		ctx:       ctx,
-		workspace: flags.GetWorkspace(cmd), // Local workspace directory for cloning/pushing
-		branches:  flags.GetBranches(cmd),  // Branches to operate on
-		tags:      flags.GetTags(cmd),      // Tags to operate on
+		ctx:         ctx,
+		workspace:   flags.GetWorkspace(cmd),   // Local workspace directory for cloning/pushing
+		branches:    flags.GetBranches(cmd),    // Branches to operate on
+		tags:        flags.GetTags(cmd),        // Tags to operate on
+		includeWiki: flags.GetIncludeWiki(cmd), // Mirror the wiki repository as well
 	}
 	// Get source repository URL and credentials from CLI flags
 	_, cred, _ := flags.GetFormCredential(cmd, false)
@@ -67,10 +93,30 @@ func NewCmdDoubleGit(ctx context.Context, cmd *cli.Command) (*Git, error) {
 		return rt, err
 	}
 	rt.targetCredential = targetCred // Assign target repository credential
+	if err := rt.setPushMode(cmd); err != nil {
+		return rt, err
+	}
 	// Return the initialized Git instance
 	return rt, nil
 }
 
+// setPushMode reads --no-force, --force-with-lease, --protected-branches, --atomic and --push-option.
+func (g *Git) setPushMode(cmd *cli.Command) error {
+	mode, err := flags.GetPushMode(cmd)
+	if err != nil {
+		return err
+	}
+	options, err := flags.GetPushOptions(cmd)
+	if err != nil {
+		return err
+	}
+	g.pushMode = mode
+	g.protected = flags.GetProtectedBranches(cmd)
+	g.atomic = flags.GetAtomic(cmd)
+	g.pushOptions = options
+	return nil
+}
+
 func (g *Git) getGitPath() (string, error) {
 	return g.credential.GetCategoryNamWorkspace(credential.WorkspaceCategoryGit, g.workspace)
 }
@@ -84,24 +130,35 @@ func (g *Git) Clone() error {
 	if err != nil {
 		return err
 	}
-	logx.Info("Start cloning repository: %s to local %s", g.credential.CloneURL, workspace)
+	logx.Info("Start cloning repository: %s to local %s", g.credential.DisplayURL(), workspace)
 	// Clone 仓库并获取实际分支和标签
 	_, _, err = migrate.Clone(workspace, []string{}, []string{})
 	if err != nil {
 		return fmt.Errorf("failed to clone repository: %w", err)
 	}
 	elapsed := time.Since(start)
-	logx.Info("Clone %s completed in %s", g.credential.CloneURL, elapsed)
+	logx.Info("Clone %s completed in %s", g.credential.DisplayURL(), elapsed)
 	return nil
 }
 
+// Push fetches the source and pushes it to the target repository.
 func (g *Git) Push() error {
-	logx.Info("Starting git sync from %s to %s", g.credential.CloneURL, g.targetCredential.CloneURL)
-	start := time.Now()
-	migrate := gitx.NewGitMigrateDouble(g.credential, g.targetCredential)
+	return g.PushTo(g.targetCredential)
+}
+
+// Fetch clones the source into the workspace, or updates an existing clone.
+// It runs once per Git instance, so every target pushed with PushTo shares a single fetch.
+func (g *Git) Fetch() error {
+	if g.fetched {
+		return g.fetchErr
+	}
+	g.fetched = true
+	migrate := gitx.NewGitMigrate()
+	migrate.WithForm(g.credential)
 	// 获取 workspace
 	workspace, err := g.getGitPath()
 	if err != nil {
+		g.fetchErr = err
 		return err
 	}
 	// 获取分支和标签
@@ -112,15 +169,141 @@ func (g *Git) Push() error {
 	// Clone 仓库并获取实际分支和标签
 	actualBranches, actualTags, err := migrate.Clone(workspace, branches, tags)
 	if err != nil {
-		return fmt.Errorf("failed to clone repository: %w", err)
+		g.fetchErr = fmt.Errorf("failed to clone repository: %w", err)
+		return g.fetchErr
 	}
 	logx.Info("Repository cloned successfully. Cloned branches: %v, tags: %v", actualBranches, actualTags)
+	g.migrate = migrate
+	g.gitPath = workspace
+	g.fetchedBranches = actualBranches
+	return nil
+}
 
-	// Push 到目标仓库
-	if err := migrate.Push(workspace); err != nil {
+// PushTo pushes the fetched source to target, then sets its default branch and mirrors the wiki.
+func (g *Git) PushTo(target *credential.Credential) error {
+	logx.Info("Starting git sync from %s to %s", g.credential.DisplayURL(), target.DisplayURL())
+	start := time.Now()
+	if err := g.Fetch(); err != nil {
 		return err
 	}
+	// Push 到目标仓库
+	g.migrate.WithTarget(target)
+	g.migrate.WithPushMode(g.pushMode, g.protected)
+	g.migrate.WithPushOptions(g.atomic, g.pushOptions)
+	// 被拒绝的引用逐个报告，其余引用已推送，仍继续设置默认分支和同步 Wiki
+	var rejectedErr *gitx.RejectedError
+	pushErr := g.migrate.Push(g.gitPath)
+	if errors.As(pushErr, &rejectedErr) {
+		for _, ref := range rejectedErr.Refs {
+			logx.Warn("Rejected %s on %s: %s (source %s, target %s)", ref.Ref, target.DisplayURL(), ref.Reason, ref.Source, ref.Target)
+		}
+	} else if pushErr != nil {
+		return pushErr
+	}
+	if head := g.migrate.Head(); head != "" && slices.Contains(g.fetchedBranches, head) {
+		if err := g.setTargetDefaultBranch(target, head); err != nil {
+			logx.Warn("Failed to set default branch of %s to %s: %v", target.DisplayURL(), head, err)
+		}
+	}
+	if g.includeWiki {
+		if err := g.pushWiki(target); err != nil {
+			logx.Warn("Failed to sync wiki of %s: %v", g.credential.DisplayURL(), err)
+		}
+	}
+	if pushErr != nil {
+		return pushErr
+	}
 	elapsed := time.Since(start)
 	logx.Info("Push to target repository completed successfully, total elapsed time: %s", elapsed)
 	return nil
 }
+
+// pushWiki mirrors the source <repo>.wiki.git repository to the target.
+// The wiki is enabled on the target through the platform API first; a source without wiki pages is skipped.
+func (g *Git) pushWiki(target *credential.Credential) error {
+	wikiCredential := *g.credential
+	wikiURL, err := x.RepoWikiURL(g.credential.CloneURL)
+	if err != nil {
+		return err
+	}
+	wikiCredential.CloneURL = wikiURL
+	targetWikiCredential := *target
+	targetWikiURL, err := x.RepoWikiURL(target.CloneURL)
+	if err != nil {
+		return err
+	}
+	targetWikiCredential.CloneURL = targetWikiURL
+
+	migrate := gitx.NewGitMigrateDouble(&wikiCredential, &targetWikiCredential)
+	migrate.WithPushMode(g.pushMode, g.protected)
+	migrate.WithPushOptions(g.atomic, g.pushOptions)
+	if !migrate.HasRefs() {
+		logx.Info("No wiki found at %s, skipping", wikiURL)
+		return nil
+	}
+	if err := g.enableTargetWiki(target); err != nil {
+		logx.Warn("Failed to enable wiki on %s: %v", target.DisplayURL(), err)
+	}
+	workspace, err := wikiCredential.GetCategoryNamWorkspace(credential.WorkspaceCategoryGit, g.workspace)
+	if err != nil {
+		return err
+	}
+	logx.Info("Starting wiki sync from %s to %s", wikiURL, targetWikiURL)
+	if _, _, err := migrate.Clone(workspace, []string{}, []string{}); err != nil {
+		return fmt.Errorf("failed to clone wiki: %w", err)
+	}
+	if err := migrate.Push(workspace); err != nil {
+		return err
+	}
+	logx.Info("Wiki pushed to %s", targetWikiURL)
+	return nil
+}
+
+// enableTargetWiki turns on the wiki of the target repository through the platform API.
+func (g *Git) enableTargetWiki(target *credential.Credential) error {
+	targetURL, err := url.Parse(target.CloneURL)
+	if err != nil {
+		return err
+	}
+	platform, err := platforms.GetPlatform(targetURL, target)
+	if err != nil {
+		return err
+	}
+	wikiPlatform, ok := platform.(platforms.IPlatformWiki)
+	if !ok {
+		logx.Warn("Platform %s does not support enabling the wiki, pushing anyway", targetURL.Host)
+		return nil
+	}
+	fullName, err := target.GetFullName()
+	if err != nil {
+		return err
+	}
+	return wikiPlatform.EnableWiki(g.ctx, fullName)
+}
+
+// setTargetDefaultBranch sets the default branch of the target repository through the platform API,
+// so the target landing page matches the source HEAD instead of the first pushed branch.
+func (g *Git) setTargetDefaultBranch(target *credential.Credential, branch string) error {
+	targetURL, err := url.Parse(target.CloneURL)
+	if err != nil {
+		return err
+	}
+	platform, err := platforms.GetPlatform(targetURL, target)
+	if err != nil {
+		return err
+	}
+	defaultBranchPlatform, ok := platform.(platforms.IPlatformDefaultBranch)
+	if !ok {
+		logx.Warn("Platform %s does not support changing the default branch, skipping", targetURL.Host)
+		return nil
+	}
+	fullName, err := target.GetFullName()
+	if err != nil {
+		return err
+	}
+	if err := defaultBranchPlatform.SetDefaultBranch(g.ctx, fullName, branch); err != nil {
+		return err
+	}
+	logx.Info("Default branch of %s set to %s", fullName, branch)
+	return nil
+}
@@ -0,0 +1,148 @@
+package factory
+
+import (
+	"fmt"
+	"github.com/chihqiang/mpgrm/flags"
+	"github.com/chihqiang/mpgrm/pkg/logx"
+	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"github.com/chihqiang/mpgrm/pkg/report"
+	"os"
+	"time"
+)
+
+// HookSync replicates webhooks to the target repository or to every repository of the target organization.
+// The webhooks come from the source repository or from a JSON template file (--hooks-file).
+// Webhooks are matched by URL; missing ones are created and changed ones updated. With --prune,
+// target webhooks whose URL is not in the desired set are deleted.
+// Platforms never return existing secrets, so secrets are always taken from environment variables.
+// Returns:
+//   - error: any error encountered while reading the desired webhooks or listing target repositories.
+func (t *DoubleRepo) HookSync() error {
+	start := time.Now()
+	targetPlatform, ok := t.targetPlatform.(platforms.IPlatformHooks)
+	if !ok {
+		return fmt.Errorf("target platform does not support webhooks")
+	}
+	hooks, err := t.desiredHooks()
+	if err != nil {
+		return err
+	}
+	targets, err := t.targetFullNames()
+	if err != nil {
+		return err
+	}
+	prune := flags.GetHooksPrune(t.cmd)
+	logx.Info("Syncing %d webhooks to %d repositories", len(hooks), len(targets))
+
+	var created, updated, deleted int
+	var failedRepos []string
+	for i, targetFullName := range targets {
+		logx.Info("Processing repository %s (%d/%d)", targetFullName, i+1, len(targets))
+		repoStart := time.Now()
+		c, u, d, err := t.syncHooks(targetPlatform, targetFullName, hooks, prune)
+		created += c
+		updated += u
+		deleted += d
+		if err != nil {
+			logx.Warn("failed to sync webhooks of %s: %v", targetFullName, err)
+			failedRepos = append(failedRepos, targetFullName)
+		}
+		report.Add(targetFullName, "", repoStart, err)
+	}
+
+	elapsed := time.Since(start)
+	if len(failedRepos) > 0 {
+		logx.Warn("Webhook sync completed: %d created, %d updated, %d deleted, %d repositories failed (%v), total elapsed: %s", created, updated, deleted, len(failedRepos), failedRepos, elapsed)
+	} else {
+		logx.Info("Webhook sync completed: %d created, %d updated, %d deleted, total %d repositories, total elapsed: %s", created, updated, deleted, len(targets), elapsed)
+	}
+	return nil
+}
+
+// desiredHooks returns the webhooks to replicate, read from the template file or the source repository.
+// Hooks without a secret get the value of the --hook-secret-env environment variable.
+func (t *DoubleRepo) desiredHooks() ([]*platforms.HookInfo, error) {
+	var hooks []*platforms.HookInfo
+	if file := flags.GetHooksFile(t.cmd); file != "" {
+		loaded, err := platforms.LoadHookTemplates(file)
+		if err != nil {
+			return nil, err
+		}
+		hooks = loaded
+	} else {
+		if t.platform == nil {
+			return nil, fmt.Errorf("either --%s or --%s is required", flags.FlagsFormRepo, flags.FlagsHooksFile)
+		}
+		sourcePlatform, ok := t.platform.(platforms.IPlatformHooks)
+		if !ok {
+			return nil, fmt.Errorf("source platform does not support webhooks")
+		}
+		sourceFullName, err := t.credential.GetFullName()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get source full name: %w", err)
+		}
+		if hooks, err = sourcePlatform.ListHooks(t.ctx, sourceFullName); err != nil {
+			return nil, fmt.Errorf("failed to list source webhooks: %w", err)
+		}
+	}
+	if env := flags.GetHookSecretEnv(t.cmd); env != "" {
+		secret := os.Getenv(env)
+		if secret == "" {
+			return nil, fmt.Errorf("environment variable %s is empty", env)
+		}
+		for _, hook := range hooks {
+			if hook.Secret == "" {
+				hook.Secret = secret
+			}
+		}
+	}
+	return hooks, nil
+}
+
+// syncHooks reconciles the webhooks of a single target repository and returns how many were created, updated and deleted.
+func (t *DoubleRepo) syncHooks(target platforms.IPlatformHooks, targetFullName string, hooks []*platforms.HookInfo, prune bool) (int, int, int, error) {
+	existing, err := target.ListHooks(t.ctx, targetFullName)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	byURL := make(map[string]*platforms.HookInfo, len(existing))
+	for _, hook := range existing {
+		byURL[hook.URL] = hook
+	}
+	supported := target.HookEvents()
+	desired := make(map[string]bool, len(hooks))
+	var created, updated, deleted int
+	for _, hook := range hooks {
+		desired[hook.URL] = true
+		current, ok := byURL[hook.URL]
+		if !ok {
+			if err := target.CreateHook(t.ctx, targetFullName, hook); err != nil {
+				return created, updated, deleted, fmt.Errorf("create webhook %s: %w", hook.URL, err)
+			}
+			created++
+			continue
+		}
+		// 已有密钥无法比较，提供了密钥时总是更新
+		if hook.Secret == "" && current.SameAs(hook, supported) {
+			continue
+		}
+		update := *hook
+		update.ID = current.ID
+		if err := target.UpdateHook(t.ctx, targetFullName, &update); err != nil {
+			return created, updated, deleted, fmt.Errorf("update webhook %s: %w", hook.URL, err)
+		}
+		updated++
+	}
+	if prune {
+		for _, hook := range existing {
+			if desired[hook.URL] {
+				continue
+			}
+			if err := target.DeleteHook(t.ctx, targetFullName, hook.ID); err != nil {
+				return created, updated, deleted, fmt.Errorf("delete webhook %s: %w", hook.URL, err)
+			}
+			deleted++
+		}
+	}
+	return created, updated, deleted, nil
+}
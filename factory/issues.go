@@ -0,0 +1,148 @@
+package factory
+
+import (
+	"fmt"
+	"github.com/chihqiang/mpgrm/flags"
+	"github.com/chihqiang/mpgrm/pkg/logx"
+	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"time"
+)
+
+// IssueSync copies issues and their comments from the source repository to the target repository.
+// Migrated issues and comments carry a hidden marker with the source number/ID, so reruns
+// map existing target issues back to their source and only add what is missing.
+// Returns:
+//   - error: any error encountered while listing source or target issues.
+func (t *DoubleRepo) IssueSync() error {
+	start := time.Now()
+	sourceFullName, err := t.credential.GetFullName()
+	if err != nil {
+		return fmt.Errorf("failed to get source full name: %w", err)
+	}
+	targetFullName, err := t.targetCredential.GetFullName()
+	if err != nil {
+		return fmt.Errorf("failed to get target full name: %w", err)
+	}
+
+	// 先同步标签，保证迁移后的 Issue 能引用到同名标签
+	sourceLabels, sourceOk := t.platform.(platforms.IPlatformLabels)
+	targetLabels, targetOk := t.targetPlatform.(platforms.IPlatformLabels)
+	if sourceOk && targetOk {
+		if labels, err := sourceLabels.ListLabels(t.ctx, sourceFullName); err != nil {
+			logx.Warn("failed to list source labels: %v", err)
+		} else if created, updated, err := t.syncLabels(targetLabels, targetFullName, labels); err != nil {
+			logx.Warn("failed to sync labels: %v", err)
+		} else {
+			logx.Info("Labels synced before issues: %d created, %d updated", created, updated)
+		}
+	}
+
+	sourceIssues, err := t.platform.ListIssues(t.ctx, sourceFullName)
+	if err != nil {
+		return fmt.Errorf("failed to list source issues: %w", err)
+	}
+	targetIssues, err := t.targetPlatform.ListIssues(t.ctx, targetFullName)
+	if err != nil {
+		return fmt.Errorf("failed to list target issues: %w", err)
+	}
+	// 源 Issue 编号 -> 目标 Issue
+	migrated := make(map[string]*platforms.IssueInfo, len(targetIssues))
+	for _, issue := range targetIssues {
+		if number, ok := platforms.ParseIssueMarker(issue.Body); ok {
+			migrated[number] = issue
+		}
+	}
+	logx.Info("Found %d source issues, %d already migrated to %s", len(sourceIssues), len(migrated), targetFullName)
+
+	withAssignees := flags.GetIssueAssignees(t.cmd)
+	var created, updated, comments, failCount int
+	for i, issue := range sourceIssues {
+		logx.Info("Processing issue #%s %q (%d/%d)", issue.Number, issue.Title, i+1, len(sourceIssues))
+		target, ok := migrated[issue.Number]
+		if !ok {
+			newIssue := &platforms.IssueInfo{
+				Title:  issue.Title,
+				Body:   issue.AttributedBody(),
+				State:  issue.State,
+				Labels: issue.Labels,
+			}
+			// 不同平台的用户名未必一致，默认不迁移负责人
+			if withAssignees {
+				newIssue.Assignees = issue.Assignees
+			}
+			target, err = t.targetPlatform.CreateIssue(t.ctx, targetFullName, newIssue)
+			if err != nil {
+				logx.Warn("failed to create issue #%s: %v", issue.Number, err)
+				failCount++
+				continue
+			}
+			created++
+			logx.Info("Created issue #%s -> #%s", issue.Number, target.Number)
+		} else if target.State != issue.State {
+			if err := t.targetPlatform.UpdateIssueState(t.ctx, targetFullName, target.Number, issue.State); err != nil {
+				logx.Warn("failed to update state of issue #%s: %v", target.Number, err)
+				failCount++
+			} else {
+				updated++
+				logx.Info("Updated issue #%s state to %s", target.Number, issue.State)
+			}
+		}
+
+		n, err := t.syncIssueComments(sourceFullName, issue.Number, targetFullName, target.Number)
+		comments += n
+		if err != nil {
+			logx.Warn("failed to sync comments of issue #%s: %v", issue.Number, err)
+			failCount++
+		}
+	}
+
+	elapsed := time.Since(start)
+	if failCount > 0 {
+		logx.Warn("Issue sync completed: %d created, %d updated, %d comments added, %d failed, total %d issues, total elapsed: %s", created, updated, comments, failCount, len(sourceIssues), elapsed)
+	} else {
+		logx.Info("Issue sync completed: %d created, %d updated, %d comments added, total %d issues, total elapsed: %s", created, updated, comments, len(sourceIssues), elapsed)
+	}
+	return nil
+}
+
+// syncIssueComments adds the source comments missing on the target issue and returns how many were added.
+func (t *DoubleRepo) syncIssueComments(sourceFullName, sourceNumber, targetFullName, targetNumber string) (int, error) {
+	sourceComments, err := t.platform.ListIssueComments(t.ctx, sourceFullName, sourceNumber)
+	if err != nil {
+		return 0, err
+	}
+	return syncComments(sourceComments, func() ([]*platforms.CommentInfo, error) {
+		return t.targetPlatform.ListIssueComments(t.ctx, targetFullName, targetNumber)
+	}, func(body string) error {
+		return t.targetPlatform.CreateIssueComment(t.ctx, targetFullName, targetNumber, body)
+	})
+}
+
+// syncComments creates the source comments whose marker is not found among the target comments
+// and returns how many were added. Target comments are only listed when there is something to add.
+func syncComments(sourceComments []*platforms.CommentInfo, listTarget func() ([]*platforms.CommentInfo, error), create func(body string) error) (int, error) {
+	if len(sourceComments) == 0 {
+		return 0, nil
+	}
+	targetComments, err := listTarget()
+	if err != nil {
+		return 0, err
+	}
+	existing := make(map[string]bool, len(targetComments))
+	for _, comment := range targetComments {
+		if id, ok := platforms.ParseCommentMarker(comment.Body); ok {
+			existing[id] = true
+		}
+	}
+	var added int
+	for _, comment := range sourceComments {
+		if existing[comment.MarkerID()] {
+			continue
+		}
+		if err := create(comment.AttributedBody()); err != nil {
+			return added, err
+		}
+		added++
+	}
+	return added, nil
+}
@@ -0,0 +1,139 @@
+package factory
+
+import (
+	"fmt"
+	"github.com/chihqiang/mpgrm/flags"
+	"github.com/chihqiang/mpgrm/pkg/logx"
+	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"github.com/chihqiang/mpgrm/pkg/report"
+	"github.com/chihqiang/mpgrm/pkg/x"
+	"time"
+)
+
+// DeployKeySync replicates deploy keys to the target repository or to every repository of the target
+// organization matching --filter. The keys come from the source repository or from a JSON file (--keys-file).
+// Keys are matched by their public part; a key whose read-only flag differs is replaced. With --prune,
+// target keys that are not in the desired set are deleted.
+// Returns:
+//   - error: any error encountered while reading the desired keys or listing target repositories.
+func (t *DoubleRepo) DeployKeySync() error {
+	start := time.Now()
+	targetPlatform, ok := t.targetPlatform.(platforms.IPlatformDeployKeys)
+	if !ok {
+		return fmt.Errorf("target platform does not support deploy keys")
+	}
+	keys, err := t.desiredDeployKeys()
+	if err != nil {
+		return err
+	}
+	fullNames, err := t.targetFullNames()
+	if err != nil {
+		return err
+	}
+	filter := flags.GetFilter(t.cmd)
+	var targets []string
+	for _, fullName := range fullNames {
+		if x.RepoMatch(fullName, filter) {
+			targets = append(targets, fullName)
+		}
+	}
+	prune := flags.GetKeysPrune(t.cmd)
+	logx.Info("Syncing %d deploy keys to %d repositories", len(keys), len(targets))
+
+	var added, removed int
+	var failedRepos []string
+	for i, targetFullName := range targets {
+		logx.Info("Processing repository %s (%d/%d)", targetFullName, i+1, len(targets))
+		repoStart := time.Now()
+		a, r, err := t.syncDeployKeys(targetPlatform, targetFullName, keys, prune)
+		added += a
+		removed += r
+		if err != nil {
+			logx.Warn("failed to sync deploy keys of %s: %v", targetFullName, err)
+			failedRepos = append(failedRepos, targetFullName)
+		}
+		report.Add(targetFullName, "", repoStart, err)
+	}
+
+	elapsed := time.Since(start)
+	if len(failedRepos) > 0 {
+		logx.Warn("Deploy key sync completed: %d added, %d removed, %d repositories failed (%v), total elapsed: %s", added, removed, len(failedRepos), failedRepos, elapsed)
+	} else {
+		logx.Info("Deploy key sync completed: %d added, %d removed, total %d repositories, total elapsed: %s", added, removed, len(targets), elapsed)
+	}
+	return nil
+}
+
+// desiredDeployKeys returns the deploy keys to replicate, read from the key file or the source repository.
+func (t *DoubleRepo) desiredDeployKeys() ([]*platforms.DeployKeyInfo, error) {
+	if file := flags.GetKeysFile(t.cmd); file != "" {
+		return platforms.LoadDeployKeys(file)
+	}
+	if t.platform == nil {
+		return nil, fmt.Errorf("either --%s or --%s is required", flags.FlagsFormRepo, flags.FlagsKeysFile)
+	}
+	sourcePlatform, ok := t.platform.(platforms.IPlatformDeployKeys)
+	if !ok {
+		return nil, fmt.Errorf("source platform does not support deploy keys")
+	}
+	sourceFullName, err := t.credential.GetFullName()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source full name: %w", err)
+	}
+	keys, err := sourcePlatform.ListDeployKeys(t.ctx, sourceFullName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list source deploy keys: %w", err)
+	}
+	return keys, nil
+}
+
+// syncDeployKeys reconciles the deploy keys of a single target repository and returns how many were added and removed.
+// Platforms cannot edit a deploy key, so a key with a different read-only flag is added again first and the
+// old key is only removed once that succeeded. When the platform rejects the new key, the old one is kept.
+func (t *DoubleRepo) syncDeployKeys(target platforms.IPlatformDeployKeys, targetFullName string, keys []*platforms.DeployKeyInfo, prune bool) (int, int, error) {
+	existing, err := target.ListDeployKeys(t.ctx, targetFullName)
+	if err != nil {
+		return 0, 0, fmt.Errorf("list target deploy keys: %w", err)
+	}
+	byFingerprint := make(map[string]*platforms.DeployKeyInfo, len(existing))
+	for _, key := range existing {
+		byFingerprint[key.Fingerprint()] = key
+	}
+	desired := make(map[string]bool, len(keys))
+	var added, removed int
+	for _, key := range keys {
+		fingerprint := key.Fingerprint()
+		desired[fingerprint] = true
+		current, ok := byFingerprint[fingerprint]
+		if ok && current.IsReadOnly() == key.IsReadOnly() {
+			continue
+		}
+		if err := target.AddDeployKey(t.ctx, targetFullName, key); err != nil {
+			if ok {
+				return added, removed, fmt.Errorf("replace deploy key %s, existing key kept: %w", key.Title, err)
+			}
+			return added, removed, fmt.Errorf("add deploy key %s: %w", key.Title, err)
+		}
+		logx.Info("Added deploy key %s to %s", key.Title, targetFullName)
+		added++
+		if ok {
+			if err := target.RemoveDeployKey(t.ctx, targetFullName, current.ID); err != nil {
+				return added, removed, fmt.Errorf("remove replaced deploy key %s: %w", key.Title, err)
+			}
+		}
+	}
+	if !prune {
+		return added, removed, nil
+	}
+	for _, key := range existing {
+		if desired[key.Fingerprint()] {
+			continue
+		}
+		if err := target.RemoveDeployKey(t.ctx, targetFullName, key.ID); err != nil {
+			return added, removed, fmt.Errorf("remove deploy key %s: %w", key.Title, err)
+		}
+		logx.Info("Removed deploy key %s from %s", key.Title, targetFullName)
+		removed++
+	}
+	return added, removed, nil
+}
@@ -0,0 +1,188 @@
+package factory
+
+import (
+	"fmt"
+	"github.com/chihqiang/mpgrm/pkg/logx"
+	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"github.com/chihqiang/mpgrm/pkg/report"
+	"time"
+)
+
+// LabelSync copies the labels of the source repository, including color and description,
+// to the target repository or to every repository of the target organization.
+// Labels are matched by name (case-insensitive); missing labels are created and changed ones updated,
+// labels that only exist on the target are kept.
+// Returns:
+//   - error: any error encountered while listing source labels or target repositories.
+func (t *DoubleRepo) LabelSync() error {
+	start := time.Now()
+	sourcePlatform, ok := t.platform.(platforms.IPlatformLabels)
+	if !ok {
+		return fmt.Errorf("source platform does not support labels")
+	}
+	targetPlatform, ok := t.targetPlatform.(platforms.IPlatformLabels)
+	if !ok {
+		return fmt.Errorf("target platform does not support labels")
+	}
+	sourceFullName, err := t.credential.GetFullName()
+	if err != nil {
+		return fmt.Errorf("failed to get source full name: %w", err)
+	}
+	labels, err := sourcePlatform.ListLabels(t.ctx, sourceFullName)
+	if err != nil {
+		return fmt.Errorf("failed to list source labels: %w", err)
+	}
+	targets, err := t.targetFullNames()
+	if err != nil {
+		return err
+	}
+	logx.Info("Syncing %d labels from %s to %d repositories", len(labels), sourceFullName, len(targets))
+
+	var created, updated int
+	var failedRepos []string
+	for i, targetFullName := range targets {
+		logx.Info("Processing repository %s (%d/%d)", targetFullName, i+1, len(targets))
+		repoStart := time.Now()
+		c, u, err := t.syncLabels(targetPlatform, targetFullName, labels)
+		created += c
+		updated += u
+		if err != nil {
+			logx.Warn("failed to sync labels of %s: %v", targetFullName, err)
+			failedRepos = append(failedRepos, targetFullName)
+		}
+		report.Add(targetFullName, "", repoStart, err)
+	}
+
+	elapsed := time.Since(start)
+	if len(failedRepos) > 0 {
+		logx.Warn("Label sync completed: %d created, %d updated, %d repositories failed (%v), total elapsed: %s", created, updated, len(failedRepos), failedRepos, elapsed)
+	} else {
+		logx.Info("Label sync completed: %d created, %d updated, total %d repositories, total elapsed: %s", created, updated, len(targets), elapsed)
+	}
+	return nil
+}
+
+// MilestoneSync copies the milestones of the source repository, including description, state and due date,
+// to the target repository or to every repository of the target organization. Milestones are matched by title.
+// Returns:
+//   - error: any error encountered while listing source milestones or target repositories.
+func (t *DoubleRepo) MilestoneSync() error {
+	start := time.Now()
+	sourcePlatform, ok := t.platform.(platforms.IPlatformMilestones)
+	if !ok {
+		return fmt.Errorf("source platform does not support milestones")
+	}
+	targetPlatform, ok := t.targetPlatform.(platforms.IPlatformMilestones)
+	if !ok {
+		return fmt.Errorf("target platform does not support milestones")
+	}
+	sourceFullName, err := t.credential.GetFullName()
+	if err != nil {
+		return fmt.Errorf("failed to get source full name: %w", err)
+	}
+	milestones, err := sourcePlatform.ListMilestones(t.ctx, sourceFullName)
+	if err != nil {
+		return fmt.Errorf("failed to list source milestones: %w", err)
+	}
+	targets, err := t.targetFullNames()
+	if err != nil {
+		return err
+	}
+	logx.Info("Syncing %d milestones from %s to %d repositories", len(milestones), sourceFullName, len(targets))
+
+	var created, updated int
+	var failedRepos []string
+	for i, targetFullName := range targets {
+		logx.Info("Processing repository %s (%d/%d)", targetFullName, i+1, len(targets))
+		repoStart := time.Now()
+		c, u, err := t.syncMilestones(targetPlatform, targetFullName, milestones)
+		created += c
+		updated += u
+		if err != nil {
+			logx.Warn("failed to sync milestones of %s: %v", targetFullName, err)
+			failedRepos = append(failedRepos, targetFullName)
+		}
+		report.Add(targetFullName, "", repoStart, err)
+	}
+
+	elapsed := time.Since(start)
+	if len(failedRepos) > 0 {
+		logx.Warn("Milestone sync completed: %d created, %d updated, %d repositories failed (%v), total elapsed: %s", created, updated, len(failedRepos), failedRepos, elapsed)
+	} else {
+		logx.Info("Milestone sync completed: %d created, %d updated, total %d repositories, total elapsed: %s", created, updated, len(targets), elapsed)
+	}
+	return nil
+}
+
+// syncLabels creates or updates labels on a single target repository and returns how many were created and updated.
+func (t *DoubleRepo) syncLabels(target platforms.IPlatformLabels, targetFullName string, labels []*platforms.LabelInfo) (int, int, error) {
+	existing, err := target.ListLabels(t.ctx, targetFullName)
+	if err != nil {
+		return 0, 0, err
+	}
+	byName := make(map[string]*platforms.LabelInfo, len(existing))
+	for _, label := range existing {
+		byName[platforms.LabelKey(label.Name)] = label
+	}
+	var created, updated int
+	for _, label := range labels {
+		current, ok := byName[platforms.LabelKey(label.Name)]
+		if !ok {
+			if err := target.CreateLabel(t.ctx, targetFullName, label); err != nil {
+				return created, updated, fmt.Errorf("create label %q: %w", label.Name, err)
+			}
+			created++
+			continue
+		}
+		if current.SameAs(label, target.LabelDescription()) {
+			continue
+		}
+		if err := target.UpdateLabel(t.ctx, targetFullName, &platforms.LabelInfo{
+			ID:          current.ID,
+			Name:        current.Name,
+			Color:       label.Color,
+			Description: label.Description,
+		}); err != nil {
+			return created, updated, fmt.Errorf("update label %q: %w", label.Name, err)
+		}
+		updated++
+	}
+	return created, updated, nil
+}
+
+// syncMilestones creates or updates milestones on a single target repository and returns how many were created and updated.
+func (t *DoubleRepo) syncMilestones(target platforms.IPlatformMilestones, targetFullName string, milestones []*platforms.MilestoneInfo) (int, int, error) {
+	existing, err := target.ListMilestones(t.ctx, targetFullName)
+	if err != nil {
+		return 0, 0, err
+	}
+	byTitle := make(map[string]*platforms.MilestoneInfo, len(existing))
+	for _, milestone := range existing {
+		byTitle[milestone.Title] = milestone
+	}
+	var created, updated int
+	for _, milestone := range milestones {
+		current, ok := byTitle[milestone.Title]
+		if !ok {
+			if err := target.CreateMilestone(t.ctx, targetFullName, milestone); err != nil {
+				return created, updated, fmt.Errorf("create milestone %q: %w", milestone.Title, err)
+			}
+			created++
+			continue
+		}
+		if current.SameAs(milestone) {
+			continue
+		}
+		if err := target.UpdateMilestone(t.ctx, targetFullName, &platforms.MilestoneInfo{
+			Number:      current.Number,
+			Title:       current.Title,
+			Description: milestone.Description,
+			State:       milestone.State,
+			DueOn:       milestone.DueOn,
+		}); err != nil {
+			return created, updated, fmt.Errorf("update milestone %q: %w", milestone.Title, err)
+		}
+		updated++
+	}
+	return created, updated, nil
+}
@@ -0,0 +1,96 @@
+package factory
+
+import (
+	"errors"
+	"fmt"
+	"github.com/chihqiang/mpgrm/flags"
+	"github.com/chihqiang/mpgrm/pkg/logx"
+	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"github.com/chihqiang/mpgrm/pkg/report"
+	"time"
+)
+
+// ProtectionSync applies branch protection rules to the target repository or to every repository
+// of the target organization. The rules come from the source repository or from a JSON policy file
+// (--policy-file). Each rule is translated to the closest setting the target platform supports;
+// rules the platform rejects are reported and skipped, and rules with settings the platform cannot
+// enforce are applied partially and reported with a warning.
+// Returns:
+//   - error: any error encountered while reading the desired rules or listing target repositories.
+func (t *DoubleRepo) ProtectionSync() error {
+	start := time.Now()
+	targetPlatform, ok := t.targetPlatform.(platforms.IPlatformProtection)
+	if !ok {
+		return fmt.Errorf("target platform does not support branch protection")
+	}
+	rules, err := t.desiredProtections()
+	if err != nil {
+		return err
+	}
+	targets, err := t.targetFullNames()
+	if err != nil {
+		return err
+	}
+	logx.Info("Applying %d branch protection rules to %d repositories", len(rules), len(targets))
+
+	var applied, partial, failed int
+	var failedRepos []string
+	for i, targetFullName := range targets {
+		logx.Info("Processing repository %s (%d/%d)", targetFullName, i+1, len(targets))
+		repoStart := time.Now()
+		var repoErr error
+		for _, rule := range rules {
+			err := targetPlatform.SetProtection(t.ctx, targetFullName, rule)
+			var unenforced *platforms.UnenforcedError
+			if errors.As(err, &unenforced) {
+				logx.Warn("branch %s of %s partially protected: %v", rule.Branch, targetFullName, err)
+				partial++
+				continue
+			}
+			if err != nil {
+				logx.Warn("failed to protect branch %s of %s: %v", rule.Branch, targetFullName, err)
+				failed++
+				repoErr = errors.Join(repoErr, fmt.Errorf("branch %s: %w", rule.Branch, err))
+				continue
+			}
+			applied++
+		}
+		if repoErr != nil {
+			failedRepos = append(failedRepos, targetFullName)
+		}
+		report.Add(targetFullName, "", repoStart, repoErr)
+	}
+
+	elapsed := time.Since(start)
+	if len(failedRepos) > 0 {
+		logx.Warn("Branch protection sync completed: %d rules applied, %d partially applied, %d failed, %d repositories failed (%v), total elapsed: %s", applied, partial, failed, len(failedRepos), failedRepos, elapsed)
+	} else if partial > 0 {
+		logx.Warn("Branch protection sync completed: %d rules applied, %d partially applied, total %d repositories, total elapsed: %s", applied, partial, len(targets), elapsed)
+	} else {
+		logx.Info("Branch protection sync completed: %d rules applied, total %d repositories, total elapsed: %s", applied, len(targets), elapsed)
+	}
+	return nil
+}
+
+// desiredProtections returns the branch protection rules to apply, read from the policy file or the source repository.
+func (t *DoubleRepo) desiredProtections() ([]*platforms.ProtectionRule, error) {
+	if file := flags.GetPolicyFile(t.cmd); file != "" {
+		return platforms.LoadProtectionRules(file)
+	}
+	if t.platform == nil {
+		return nil, fmt.Errorf("either --%s or --%s is required", flags.FlagsFormRepo, flags.FlagsPolicyFile)
+	}
+	sourcePlatform, ok := t.platform.(platforms.IPlatformProtection)
+	if !ok {
+		return nil, fmt.Errorf("source platform does not support branch protection")
+	}
+	sourceFullName, err := t.credential.GetFullName()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source full name: %w", err)
+	}
+	rules, err := sourcePlatform.ListProtections(t.ctx, sourceFullName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list source branch protections: %w", err)
+	}
+	return rules, nil
+}
@@ -0,0 +1,182 @@
+package factory
+
+import (
+	"fmt"
+	"github.com/chihqiang/mpgrm/flags"
+	"github.com/chihqiang/mpgrm/pkg/credential"
+	"github.com/chihqiang/mpgrm/pkg/gitx"
+	"github.com/chihqiang/mpgrm/pkg/logx"
+	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"time"
+)
+
+// PullSync recreates the pull requests of the source repository on the target repository.
+// In flags.PullModeNative the head of every pull request is mirrored from refs/pull/<n>/head to a
+// branch on the target and a native pull request is opened; when that is not possible the pull request
+// falls back to a read-only issue. In flags.PullModeIssue every pull request becomes an issue holding
+// the diff summary, merge status and review comments. Reruns only add what is missing.
+// Returns:
+//   - error: any error encountered while listing source or target pull requests.
+func (t *DoubleRepo) PullSync(mode string) error {
+	start := time.Now()
+	sourcePulls, ok := t.platform.(platforms.IPlatformPulls)
+	if !ok {
+		return fmt.Errorf("source platform does not support pull requests")
+	}
+	targetPulls, native := t.targetPlatform.(platforms.IPlatformPulls)
+	if mode == flags.PullModeNative && !native {
+		logx.Warn("Target platform does not support pull requests, falling back to issues")
+	}
+	native = native && mode == flags.PullModeNative
+
+	sourceFullName, err := t.credential.GetFullName()
+	if err != nil {
+		return fmt.Errorf("failed to get source full name: %w", err)
+	}
+	targetFullName, err := t.targetCredential.GetFullName()
+	if err != nil {
+		return fmt.Errorf("failed to get target full name: %w", err)
+	}
+
+	pulls, err := sourcePulls.ListPulls(t.ctx, sourceFullName)
+	if err != nil {
+		return fmt.Errorf("failed to list source pull requests: %w", err)
+	}
+
+	// 源合并请求编号 -> 目标合并请求或 Issue 编号，两种形式都通过迁移标记识别
+	migratedPulls := make(map[string]string)
+	if native {
+		targets, err := targetPulls.ListPulls(t.ctx, targetFullName)
+		if err != nil {
+			return fmt.Errorf("failed to list target pull requests: %w", err)
+		}
+		for _, pull := range targets {
+			if number, ok := platforms.ParsePullMarker(pull.Body); ok {
+				migratedPulls[number] = pull.Number
+			}
+		}
+	}
+	migratedIssues := make(map[string]string)
+	targetIssues, err := t.targetPlatform.ListIssues(t.ctx, targetFullName)
+	if err != nil {
+		return fmt.Errorf("failed to list target issues: %w", err)
+	}
+	for _, issue := range targetIssues {
+		if number, ok := platforms.ParsePullMarker(issue.Body); ok {
+			migratedIssues[number] = issue.Number
+		}
+	}
+	logx.Info("Found %d source pull requests, %d already migrated as pull requests, %d as issues", len(pulls), len(migratedPulls), len(migratedIssues))
+
+	var mirror *gitx.GitMigrate
+	var mirrorPath string
+	if native {
+		mirror = gitx.NewGitMigrateDouble(t.credential, t.targetCredential)
+		if mirrorPath, err = t.credential.GetCategoryNamWorkspace(credential.WorkspaceCategoryPulls, flags.GetWorkspace(t.cmd)); err != nil {
+			return err
+		}
+	}
+
+	var asPulls, asIssues, comments, failCount int
+	for i, pull := range pulls {
+		logx.Info("Processing pull request #%s %q (%d/%d)", pull.Number, pull.Title, i+1, len(pulls))
+		sourceComments, err := sourcePulls.ListPullComments(t.ctx, sourceFullName, pull.Number)
+		if err != nil {
+			logx.Warn("failed to list comments of pull request #%s: %v", pull.Number, err)
+			failCount++
+			continue
+		}
+
+		// 已迁移为原生合并请求
+		number, ok := migratedPulls[pull.Number]
+		if !ok && native {
+			if _, migrated := migratedIssues[pull.Number]; !migrated {
+				number, err = t.createNativePull(mirror, mirrorPath, targetPulls, targetFullName, pull)
+				if err != nil {
+					logx.Warn("failed to recreate pull request #%s natively, falling back to issue: %v", pull.Number, err)
+				} else {
+					ok = true
+					asPulls++
+					logx.Info("Created pull request #%s -> #%s", pull.Number, number)
+				}
+			}
+		}
+		if ok {
+			n, err := syncComments(sourceComments, func() ([]*platforms.CommentInfo, error) {
+				return targetPulls.ListPullComments(t.ctx, targetFullName, number)
+			}, func(body string) error {
+				return targetPulls.CreatePullComment(t.ctx, targetFullName, number, body)
+			})
+			comments += n
+			if err != nil {
+				logx.Warn("failed to sync comments of pull request #%s: %v", pull.Number, err)
+				failCount++
+			}
+			continue
+		}
+
+		// 以只读 Issue 形式归档
+		number, ok = migratedIssues[pull.Number]
+		if !ok {
+			files, err := sourcePulls.ListPullFiles(t.ctx, sourceFullName, pull.Number)
+			if err != nil {
+				logx.Warn("failed to list files of pull request #%s: %v", pull.Number, err)
+			}
+			state := platforms.IssueStateClosed
+			if pull.State == platforms.PullStateOpen {
+				state = platforms.IssueStateOpen
+			}
+			issue, err := t.targetPlatform.CreateIssue(t.ctx, targetFullName, &platforms.IssueInfo{
+				Title: pull.IssueTitle(),
+				Body:  pull.IssueBody(files),
+				State: state,
+			})
+			if err != nil {
+				logx.Warn("failed to create issue for pull request #%s: %v", pull.Number, err)
+				failCount++
+				continue
+			}
+			number = issue.Number
+			asIssues++
+			logx.Info("Created issue for pull request #%s -> #%s", pull.Number, number)
+		}
+		n, err := syncComments(sourceComments, func() ([]*platforms.CommentInfo, error) {
+			return t.targetPlatform.ListIssueComments(t.ctx, targetFullName, number)
+		}, func(body string) error {
+			return t.targetPlatform.CreateIssueComment(t.ctx, targetFullName, number, body)
+		})
+		comments += n
+		if err != nil {
+			logx.Warn("failed to sync comments of pull request #%s: %v", pull.Number, err)
+			failCount++
+		}
+	}
+
+	elapsed := time.Since(start)
+	if failCount > 0 {
+		logx.Warn("Pull request sync completed: %d as pull requests, %d as issues, %d comments added, %d failed, total %d pull requests, total elapsed: %s", asPulls, asIssues, comments, failCount, len(pulls), elapsed)
+	} else {
+		logx.Info("Pull request sync completed: %d as pull requests, %d as issues, %d comments added, total %d pull requests, total elapsed: %s", asPulls, asIssues, comments, len(pulls), elapsed)
+	}
+	return nil
+}
+
+// createNativePull mirrors the head of the source pull request to a branch on the target
+// and opens a pull request from it, returning the target pull request number.
+func (t *DoubleRepo) createNativePull(mirror *gitx.GitMigrate, mirrorPath string, target platforms.IPlatformPulls, targetFullName string, pull *platforms.PullInfo) (string, error) {
+	branch := platforms.PullBranch(pull.Number)
+	if err := mirror.MirrorRef(mirrorPath, platforms.PullRef(pull.Number), branch); err != nil {
+		return "", err
+	}
+	created, err := target.CreatePull(t.ctx, targetFullName, &platforms.PullInfo{
+		Title: pull.Title,
+		Body:  pull.AttributedBody(),
+		State: pull.State,
+		Head:  branch,
+		Base:  pull.Base,
+	})
+	if err != nil {
+		return "", err
+	}
+	return created.Number, nil
+}
@@ -7,9 +7,13 @@ import (
 	"github.com/chihqiang/mpgrm/pkg/credential"
 	"github.com/chihqiang/mpgrm/pkg/logx"
 	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"github.com/chihqiang/mpgrm/pkg/report"
 	"github.com/chihqiang/mpgrm/pkg/x"
 	"github.com/urfave/cli/v3"
 	"net/url"
+	"path"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 )
@@ -56,7 +60,10 @@ func (r *Repo) ListRepo() (repo []*platforms.RepoInfo, err error) {
 	}
 	logx.Info("Parsed organization/subpath: %s", orgName)
 	logx.Info("Fetching repositories from platform...")
-	if orgName != "" {
+	if orgName != "" && flags.GetRecursive(r.cmd) {
+		logx.Info("Organization/subpath detected: %s, fetching org repositories recursively...", orgName)
+		repo, err = r.listOrgRepoRecursive(orgName)
+	} else if orgName != "" {
 		logx.Info("Organization/subpath detected: %s, fetching org repositories...", orgName)
 		repo, err = r.platform.ListOrgRepo(r.ctx, orgName)
 	} else {
@@ -67,13 +74,44 @@ func (r *Repo) ListRepo() (repo []*platforms.RepoInfo, err error) {
 		return nil, err
 	}
 	logx.Info("Successfully fetched %d repositories", len(repo))
-	// 遍历仓库并打印名称
+	// 遍历仓库并打印名称及元数据
 	for _, rInfo := range repo {
-		logx.Info("  - %s", rInfo.CloneURL)
+		visibility := "public"
+		if rInfo.IsPrivate {
+			visibility = "private"
+		}
+		logx.Info("  - %s [%s] language=%s license=%s stars=%d forks=%d size=%dKB topics=%v",
+			rInfo.CloneURL, visibility, rInfo.Language, rInfo.License, rInfo.Stars, rInfo.Forks, rInfo.Size, rInfo.Topics)
 	}
 	return repo, nil
 }
 
+// listOrgRepoRecursive lists the repositories of orgName and of all its sub-organizations.
+func (r *Repo) listOrgRepoRecursive(orgName string) ([]*platforms.RepoInfo, error) {
+	repos, err := r.platform.ListOrgRepo(r.ctx, orgName)
+	if err != nil {
+		return nil, err
+	}
+	subOrgPlatform, ok := r.platform.(platforms.IPlatformSubOrgs)
+	if !ok {
+		logx.Warn("Platform does not support sub-organizations, only %s is listed", orgName)
+		return repos, nil
+	}
+	subOrgs, err := subOrgPlatform.ListSubOrgs(r.ctx, orgName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sub-organizations of %s: %w", orgName, err)
+	}
+	for _, subOrg := range subOrgs {
+		logx.Info("Walking sub-organization: %s", subOrg)
+		subRepos, err := r.listOrgRepoRecursive(subOrg)
+		if err != nil {
+			return nil, err
+		}
+		repos = append(repos, subRepos...)
+	}
+	return repos, nil
+}
+
 func (r *Repo) CloneRepo() error {
 	repo, err := r.ListRepo()
 	if err != nil {
@@ -106,20 +144,86 @@ func (r *Repo) CloneRepo() error {
 	wg.Wait()
 	return nil
 }
+
+// syncTarget describes where a source repository lands on the target platform.
+type syncTarget struct {
+	SubPath string // 相对目标组织的子组织路径，例如 "child/child2"，为空表示直接位于目标组织下
+	Name    string // 目标仓库名
+}
+
+// credential returns a copy of base pointing at the target repository, so that
+// every repository uses its own target credential and CloneURL is never overwritten.
+func (t *syncTarget) credential(base *credential.Credential) (*credential.Credential, error) {
+	c := *base
+	if t.SubPath != "" {
+		c.CloneURL = fmt.Sprintf("%s/%s/", strings.TrimSuffix(c.CloneURL, "/"), t.SubPath)
+	}
+	if err := c.SetCloneByRepoName(t.Name); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// syncDestination is one --target-repo of a repository sync, with everything resolved once per run.
+type syncDestination struct {
+	url           *url.URL
+	credential    *credential.Credential
+	platform      platforms.IPlatform
+	org           string                           // 目标组织，为空表示用户个人仓库
+	targets       map[string]*syncTarget           // 源仓库全名到目标位置的映射
+	subOrgs       platforms.IPlatformSubOrgs       // 重建子组织层级时使用
+	mirror        platforms.IPlatformServerMirror  // 使用服务端镜像时不为 nil
+	collaborators platforms.IPlatformCollaborators // 同步协作者时不为 nil
+	knownOrgs     map[string]bool
+}
+
 func (r *Repo) RepoSync() error {
-	targetURL, targetCredential, err := flags.GetTargetCredential(r.cmd)
+	targetCredentials, err := flags.GetTargetCredentials(r.cmd)
 	if err != nil {
 		return err
 	}
-	logx.Info("Target URL parsed: %s", targetURL.String())
-	targetPlatform, err := platforms.GetPlatform(targetURL, targetCredential)
+	nameRule, err := flags.GetRepoNameRule(r.cmd)
 	if err != nil {
 		return err
 	}
+	metadataFields, err := flags.GetMetadataFields(r.cmd)
+	if err != nil {
+		return err
+	}
+	sourceOrg, err := x.RepoURLParseOrgName(r.repoURL)
+	if err != nil {
+		return err
+	}
+	// 提供用户映射时同时迁移协作者，避免新仓库无人可以推送
+	var mapping *platforms.UserMapping
+	if flags.HasUserMapping(r.cmd) {
+		if mapping, err = flags.GetUserMapping(r.cmd); err != nil {
+			return err
+		}
+	}
+	sourceCollaborators, _ := r.platform.(platforms.IPlatformCollaborators)
+	if mapping != nil && sourceCollaborators == nil {
+		logx.Warn("Collaborators will not be synced: platform does not support collaborators")
+		mapping = nil
+	}
 	repos, err := r.ListRepo()
 	if err != nil {
 		return err
 	}
+	if filter := flags.GetFilter(r.cmd); len(filter) > 0 {
+		repos = slices.DeleteFunc(repos, func(repo *platforms.RepoInfo) bool {
+			return !x.RepoMatch(repo.FullName, filter)
+		})
+		logx.Info("%d repositories match filter %v", len(repos), filter)
+	}
+	destinations := make([]*syncDestination, 0, len(targetCredentials))
+	for _, targetCredential := range targetCredentials {
+		destination, err := r.newSyncDestination(targetCredential, repos, nameRule, sourceOrg, mapping != nil)
+		if err != nil {
+			return err
+		}
+		destinations = append(destinations, destination)
+	}
 	logx.Info("Starting repository sync...")
 	var wg sync.WaitGroup
 	var mu sync.Mutex
@@ -130,44 +234,19 @@ func (r *Repo) RepoSync() error {
 			defer wg.Done()
 			mu.Lock()
 			defer mu.Unlock()
-			start := time.Now()
-			cloneURL := repo.CloneURL
-			if err := targetCredential.SetCloneByRepoName(repo.Name); err != nil {
-				logx.Error(err.Error())
-				return
-			}
-			r.credential.CloneURL = cloneURL
-			logx.Info("Source URL: %s", cloneURL)
+			r.credential.CloneURL = repo.CloneURL
+			logx.Info("Source URL: %s", repo.CloneURL)
 			logx.Info("Source Credential %s", r.credential)
-			logx.Info("Target URL: %s", targetCredential.CloneURL)
-			logx.Info("Target Credential %s", targetCredential)
-			doubleCredentialGit, err := NewDoubleCredentialGit(r.cmd, r.credential, targetCredential)
+			// 所有目标共用同一次拉取
+			git, err := NewCredentialGit(r.cmd, r.credential)
 			if err != nil {
-				logx.Error("Failed to create Git instance for %s: %v", targetCredential.CloneURL, err)
+				logx.Error("Failed to create Git instance for %s: %v", repo.CloneURL, err)
+				report.Add(repo.CloneURL, "", time.Now(), err)
 				return
 			}
-			logx.Info("Git instance created for %s", targetCredential.CloneURL)
-			targetFullName, _ := targetCredential.GetFullName()
-			detail, err := targetPlatform.GetRepoDetail(r.ctx, targetFullName)
-			if err != nil || detail.ID == 0 {
-				logx.Warn("Target repository %s does not exist or cannot be fetched, creating...", targetCredential.CloneURL)
-				if createErr := targetPlatform.CreateRepo(r.ctx, &platforms.RepoInfo{
-					Name:        repo.Name,
-					IsPrivate:   repo.IsPrivate,
-					FullName:    targetFullName,
-					Description: repo.Description,
-					Homepage:    repo.Homepage,
-				}); createErr != nil {
-					logx.Error("create target repository %s: %v", targetCredential.CloneURL, createErr)
-					return
-				}
+			for _, destination := range destinations {
+				r.syncRepoTo(destination, git, repo, metadataFields, sourceCollaborators, mapping)
 			}
-			logx.Info("Pushing repository: %s", targetCredential.CloneURL)
-			if err := doubleCredentialGit.Push(); err != nil {
-				logx.Error("push %s: %v", targetCredential.CloneURL, err)
-				return
-			}
-			logx.Info("Repository %s synced successfully (took %s)", targetCredential.CloneURL, time.Since(start))
 		}(repo)
 	}
 	wg.Wait()
@@ -175,6 +254,297 @@ func (r *Repo) RepoSync() error {
 	return nil
 }
 
+// newSyncDestination resolves the platform, repository names and optional capabilities of a target
+// and creates the target organization when needed.
+func (r *Repo) newSyncDestination(targetCredential *credential.Credential, repos []*platforms.RepoInfo, nameRule *x.RepoNameRule, sourceOrg string, withCollaborators bool) (*syncDestination, error) {
+	targetURL, err := url.Parse(targetCredential.CloneURL)
+	if err != nil {
+		return nil, err
+	}
+	logx.Info("Target URL parsed: %s", targetURL.String())
+	targetPlatform, err := platforms.GetPlatform(targetURL, targetCredential)
+	if err != nil {
+		return nil, err
+	}
+	targetOrg, err := x.RepoURLParseOrgName(targetURL)
+	if err != nil {
+		return nil, err
+	}
+	d := &syncDestination{
+		url:        targetURL,
+		credential: targetCredential,
+		platform:   targetPlatform,
+		org:        targetOrg,
+		knownOrgs:  make(map[string]bool),
+	}
+	if withCollaborators {
+		var ok bool
+		if d.collaborators, ok = targetPlatform.(platforms.IPlatformCollaborators); !ok {
+			logx.Warn("Collaborators will not be synced to %s: platform does not support collaborators", targetURL.Host)
+		}
+	}
+	// 目标平台支持服务端镜像时由目标平台定期拉取，否则回退到本地推送
+	if flags.GetServerMirror(r.cmd) {
+		var ok bool
+		if d.mirror, ok = targetPlatform.(platforms.IPlatformServerMirror); !ok {
+			logx.Warn("Target platform %s does not support server-side mirrors, falling back to local push", targetURL.Host)
+		}
+	}
+	recursive := flags.GetRecursive(r.cmd)
+	if recursive && strings.HasSuffix(targetURL.Path, ".git") {
+		return nil, fmt.Errorf("--%s requires an organization target URL, got %s", flags.FlagsRecursive, targetURL.String())
+	}
+	// 目标平台支持嵌套组织时重建层级，否则按分隔符扁平化
+	subOrgs, nested := targetPlatform.(platforms.IPlatformSubOrgs)
+	nested = nested && recursive && targetOrg != ""
+	d.subOrgs = subOrgs
+	if d.targets, err = r.resolveSyncTargets(repos, nameRule, sourceOrg, nested); err != nil {
+		return nil, err
+	}
+	if targetOrg != "" {
+		if err := r.ensureTargetOrg(targetPlatform, sourceOrg, targetOrg); err != nil {
+			logx.Warn("%v", err)
+		}
+	}
+	return d, nil
+}
+
+// syncRepoTo syncs a source repository to one destination, pushing from the shared fetch in git.
+func (r *Repo) syncRepoTo(d *syncDestination, git *Git, repo *platforms.RepoInfo, metadataFields []platforms.RepoField, sourceCollaborators platforms.IPlatformCollaborators, mapping *platforms.UserMapping) {
+	start := time.Now()
+	cloneURL := repo.CloneURL
+	target := d.targets[repo.FullName]
+	if target.SubPath != "" {
+		if err := r.ensureSubOrgs(d.subOrgs, d.org, target.SubPath, d.knownOrgs); err != nil {
+			logx.Error("prepare target sub-organization %s: %v", target.SubPath, err)
+			report.Add(cloneURL, "", start, err)
+			return
+		}
+	}
+	repoTargetCredential, err := target.credential(d.credential)
+	if err != nil {
+		logx.Error(err.Error())
+		report.Add(cloneURL, "", start, err)
+		return
+	}
+	logx.Info("Target URL: %s", repoTargetCredential.DisplayURL())
+	logx.Info("Target Credential %s", repoTargetCredential)
+	targetFullName, _ := repoTargetCredential.GetFullName()
+	targetRepo := &platforms.RepoInfo{
+		Name:        target.Name,
+		IsPrivate:   repo.IsPrivate,
+		FullName:    targetFullName,
+		Description: repo.Description,
+		Homepage:    repo.Homepage,
+		Topics:      repo.Topics,
+	}
+	// 只有目标仓库确实不存在时才创建，查询失败时不能当作不存在
+	detail, err := d.platform.GetRepoDetail(r.ctx, targetFullName)
+	switch {
+	case platforms.IsNotFound(err), err == nil && detail.ID == 0:
+		detail = nil
+	case err != nil:
+		logx.Error("get target repository %s: %v", repoTargetCredential.DisplayURL(), err)
+		report.Add(cloneURL, repoTargetCredential.DisplayURL(), start, err)
+		return
+	}
+	mirrored := d.mirror != nil && r.syncServerMirror(d.mirror, repo, targetRepo, detail)
+	if !mirrored {
+		if detail == nil {
+			logx.Warn("Target repository %s does not exist, creating...", repoTargetCredential.DisplayURL())
+			if createErr := d.platform.CreateRepo(r.ctx, targetRepo); createErr != nil {
+				logx.Error("create target repository %s: %v", repoTargetCredential.DisplayURL(), createErr)
+				report.Add(cloneURL, repoTargetCredential.DisplayURL(), start, createErr)
+				return
+			}
+		}
+		logx.Info("Pushing repository: %s", repoTargetCredential.DisplayURL())
+		if err := git.PushTo(repoTargetCredential); err != nil {
+			logx.Error("push %s: %v", repoTargetCredential.DisplayURL(), err)
+			report.Add(cloneURL, repoTargetCredential.DisplayURL(), start, err)
+			return
+		}
+	}
+	if err := r.syncMetadata(d.platform, repo.FullName, targetFullName, metadataFields); err != nil {
+		logx.Warn("sync metadata of %s: %v", repoTargetCredential.DisplayURL(), err)
+	}
+	if mapping != nil && d.collaborators != nil {
+		if _, err := syncCollaborators(r.ctx, sourceCollaborators, d.collaborators, repo.FullName, targetFullName, mapping); err != nil {
+			logx.Warn("sync collaborators of %s: %v", repoTargetCredential.DisplayURL(), err)
+		}
+	}
+	logx.Info("Repository %s synced successfully (took %s)", repoTargetCredential.DisplayURL(), time.Since(start))
+	report.Add(cloneURL, repoTargetCredential.DisplayURL(), start, nil)
+}
+
+// syncServerMirror lets the target platform pull the repository by itself. A pull mirror is created
+// when the target repository does not exist (detail is nil), and an existing mirror is asked to sync now.
+// It returns false when the repository has to be pushed locally instead.
+func (r *Repo) syncServerMirror(target platforms.IPlatformServerMirror, repo, targetRepo, detail *platforms.RepoInfo) bool {
+	if detail != nil {
+		if !detail.IsMirror {
+			logx.Warn("Target repository %s already exists and is not a mirror, falling back to local push", targetRepo.FullName)
+			return false
+		}
+		if err := target.SyncMirror(r.ctx, targetRepo.FullName); err != nil {
+			logx.Warn("trigger mirror sync of %s: %v", targetRepo.FullName, err)
+		} else {
+			logx.Info("Triggered server-side mirror sync of %s", targetRepo.FullName)
+		}
+		return true
+	}
+	password := r.credential.Password
+	if password == "" {
+		password = r.credential.Token
+	}
+	logx.Info("Creating server-side mirror %s of %s", targetRepo.FullName, repo.CloneURL)
+	if err := target.CreateMirror(r.ctx, targetRepo, &platforms.MirrorInfo{
+		CloneURL: repo.CloneURL,
+		Username: r.credential.Username,
+		Password: password,
+		Interval: flags.GetMirrorInterval(r.cmd),
+		Wiki:     flags.GetIncludeWiki(r.cmd),
+	}); err != nil {
+		logx.Warn("create server-side mirror %s: %v, falling back to local push", targetRepo.FullName, err)
+		return false
+	}
+	return true
+}
+
+// syncMetadata reconciles the managed metadata fields of the target repository with the source repository.
+// It runs after every push so that later changes on the source are reflected on the target.
+func (r *Repo) syncMetadata(targetPlatform platforms.IPlatform, sourceFullName, targetFullName string, fields []platforms.RepoField) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	source, err := r.platform.GetRepoDetail(r.ctx, sourceFullName)
+	if err != nil {
+		return fmt.Errorf("get source repository %s: %w", sourceFullName, err)
+	}
+	target, err := targetPlatform.GetRepoDetail(r.ctx, targetFullName)
+	if err != nil {
+		return fmt.Errorf("get target repository %s: %w", targetFullName, err)
+	}
+	desired, changed := target.MergeMetadata(source, fields)
+	if !changed {
+		logx.Info("Metadata of %s is up to date", targetFullName)
+		return nil
+	}
+	desired.FullName = targetFullName
+	logx.Info("Updating metadata of %s (fields: %v)", targetFullName, fields)
+	return targetPlatform.UpdateRepo(r.ctx, desired)
+}
+
+// resolveSyncTargets computes the target location of every source repository and refuses
+// to continue when several source repositories map to the same target repository.
+// Repositories found in sub-organizations keep their hierarchy when nested is true,
+// otherwise the sub-organization path is flattened into the repository name.
+func (r *Repo) resolveSyncTargets(repos []*platforms.RepoInfo, rule *x.RepoNameRule, sourceOrg string, nested bool) (map[string]*syncTarget, error) {
+	separator := flags.GetFlattenSeparator(r.cmd)
+	targets := make(map[string]*syncTarget, len(repos))
+	targetPaths := make(map[string]string, len(repos))
+	for _, repo := range repos {
+		name, err := rule.TargetName(repo.FullName, repo.Name)
+		if err != nil {
+			return nil, err
+		}
+		target := &syncTarget{Name: name}
+		if subPath := x.RepoSubPath(sourceOrg, repo.FullName); subPath != "" {
+			switch {
+			case nested:
+				target.SubPath = subPath
+			case !rule.UsesOwner(repo.FullName, repo.Name):
+				// 模板已包含组织路径时不再重复添加子组织前缀
+				target.Name = strings.ReplaceAll(subPath, "/", separator) + separator + name
+			}
+		}
+		targetPath := path.Join(target.SubPath, target.Name)
+		if targetPath != repo.Name {
+			logx.Info("Map %s -> %s", repo.FullName, targetPath)
+		}
+		targets[repo.FullName] = target
+		targetPaths[repo.FullName] = targetPath
+	}
+	collisions := x.RepoNameCollisions(targetPaths)
+	if len(collisions) > 0 {
+		for target, sources := range collisions {
+			logx.Error("Target name %q is used by %v", target, sources)
+		}
+		return nil, fmt.Errorf("%d target repository name collision(s) detected, adjust --%s or --%s", len(collisions), flags.FlagsNameTemplate, flags.FlagsNameMap)
+	}
+	return targets, nil
+}
+
+// ensureTargetOrg creates the target organization when it does not exist yet, copying the
+// display name, description, homepage and visibility of the source organization.
+// Nothing is created when the target is the namespace of the authenticated user.
+func (r *Repo) ensureTargetOrg(targetPlatform platforms.IPlatform, sourceOrg, targetOrg string) error {
+	targetOrgs, ok := targetPlatform.(platforms.IPlatformOrgs)
+	if !ok {
+		return nil
+	}
+	_, err := targetOrgs.GetOrg(r.ctx, targetOrg)
+	if err == nil {
+		return nil
+	}
+	if !platforms.IsNotFound(err) {
+		return fmt.Errorf("get target organization %s: %w", targetOrg, err)
+	}
+	// 只配置 Token 时凭证中没有用户名，需要通过接口查询当前用户
+	login, err := targetOrgs.CurrentUser(r.ctx)
+	if err != nil {
+		return fmt.Errorf("get authenticated user: %w", err)
+	}
+	if strings.EqualFold(login, targetOrg) {
+		return nil
+	}
+	org := &platforms.OrgInfo{Name: targetOrg}
+	if sourceOrgs, ok := r.platform.(platforms.IPlatformOrgs); ok && sourceOrg != "" {
+		source, err := sourceOrgs.GetOrg(r.ctx, sourceOrg)
+		if err != nil {
+			logx.Warn("get source organization %s: %v", sourceOrg, err)
+		} else {
+			org.DisplayName = source.DisplayName
+			org.Description = source.Description
+			org.Homepage = source.Homepage
+			org.IsPrivate = source.IsPrivate
+		}
+	}
+	logx.Warn("Target organization %s does not exist, creating...", targetOrg)
+	if err := targetOrgs.CreateOrg(r.ctx, org); err != nil {
+		return fmt.Errorf("create target organization %s: %w", targetOrg, err)
+	}
+	logx.Info("Target organization %s created", targetOrg)
+	return nil
+}
+
+// ensureSubOrgs creates every missing level of subPath below rootOrg on the target platform.
+// known caches organization paths already seen or created during this run.
+func (r *Repo) ensureSubOrgs(p platforms.IPlatformSubOrgs, rootOrg, subPath string, known map[string]bool) error {
+	parent := rootOrg
+	for _, name := range strings.Split(subPath, "/") {
+		orgPath := parent + "/" + name
+		if !known[orgPath] {
+			subOrgs, err := p.ListSubOrgs(r.ctx, parent)
+			if err != nil {
+				return err
+			}
+			for _, subOrg := range subOrgs {
+				known[subOrg] = true
+			}
+		}
+		if !known[orgPath] {
+			logx.Info("Creating sub-organization %s on target", orgPath)
+			if err := p.CreateSubOrg(r.ctx, parent, name); err != nil {
+				return fmt.Errorf("create sub-organization %s: %w", orgPath, err)
+			}
+			known[orgPath] = true
+		}
+		parent = orgPath
+	}
+	return nil
+}
+
 func (r *Repo) CreateRelease() error {
 	fullName, err := r.credential.GetFullName()
 	if err != nil {
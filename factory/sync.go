@@ -0,0 +1,51 @@
+package factory
+
+import (
+	"fmt"
+	"github.com/chihqiang/mpgrm/pkg/credential"
+	"github.com/chihqiang/mpgrm/pkg/gitx"
+	"github.com/chihqiang/mpgrm/pkg/logx"
+	"strings"
+	"time"
+)
+
+// Sync brings the source and target repositories together without ever force-pushing.
+// Branches behind on the target are fast-forwarded; with bidirectional set, branches behind on the
+// source are fast-forwarded as well and refs that only exist on the target are pushed back to the source.
+// Diverged branches and tags pointing at different objects are left untouched and returned as an error.
+func (g *Git) Sync(bidirectional bool) ([]gitx.RefSync, error) {
+	start := time.Now()
+	workspace, err := g.credential.GetCategoryNamWorkspace(credential.WorkspaceCategorySync, g.workspace)
+	if err != nil {
+		return nil, err
+	}
+	mode := "one-way"
+	if bidirectional {
+		mode = "bidirectional"
+	}
+	logx.Info("Starting %s sync between %s and %s", mode, g.credential.DisplayURL(), g.targetCredential.DisplayURL())
+	migrate := gitx.NewGitMigrateDouble(g.credential, g.targetCredential)
+	results, err := migrate.SyncRefs(workspace, g.branches, g.tags, bidirectional)
+	if err != nil {
+		return results, err
+	}
+	var diverged []string
+	for _, result := range results {
+		switch result.Action {
+		case gitx.SyncUpToDate:
+			continue
+		case gitx.SyncDiverged:
+			diverged = append(diverged, result.Ref)
+			logx.Warn("%s diverged: source %s, target %s", result.Ref, result.Source, result.Target)
+		case gitx.SyncTargetOnly, gitx.SyncTargetAhead:
+			logx.Warn("%s: %s, use --bidirectional to update the source", result.Ref, result.Action)
+		default:
+			logx.Info("%s: %s", result.Ref, result.Action)
+		}
+	}
+	if len(diverged) > 0 {
+		return results, fmt.Errorf("%d ref(s) diverged and were not synced: %s", len(diverged), strings.Join(diverged, ", "))
+	}
+	logx.Info("Sync completed successfully, total elapsed time: %s", time.Since(start))
+	return results, nil
+}
@@ -0,0 +1,171 @@
+package factory
+
+import (
+	"fmt"
+	"github.com/chihqiang/mpgrm/flags"
+	"github.com/chihqiang/mpgrm/pkg/credential"
+	"github.com/chihqiang/mpgrm/pkg/drift"
+	"github.com/chihqiang/mpgrm/pkg/gitx"
+	"github.com/chihqiang/mpgrm/pkg/logx"
+	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"github.com/chihqiang/mpgrm/pkg/report"
+	"github.com/chihqiang/mpgrm/pkg/x"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"time"
+)
+
+// verifyPair is a source repository and the target repository it is mirrored to.
+type verifyPair struct {
+	source, target *credential.Credential
+}
+
+// Verify compares the source and target repositories without transferring any objects.
+// A source URL ending with ".git" compares a single repository, any other URL compares every
+// repository of the organization or user, mapped to target names the same way as repo sync.
+// Refs are compared with ls-remote; releases are compared for every source tag unless refsOnly is set.
+func (r *Repo) Verify(refsOnly bool) (*drift.Result, error) {
+	targetURL, targetCredential, err := flags.GetTargetCredential(r.cmd)
+	if err != nil {
+		return nil, err
+	}
+	targetPlatform, err := platforms.GetPlatform(targetURL, targetCredential)
+	if err != nil {
+		return nil, err
+	}
+	pairs, err := r.verifyPairs(targetURL, targetPlatform, targetCredential)
+	if err != nil {
+		return nil, err
+	}
+	result := drift.NewResult()
+	for i, pair := range pairs {
+		start := time.Now()
+		logx.Info("Verifying %s against %s (%d/%d)", pair.source.DisplayURL(), pair.target.DisplayURL(), i+1, len(pairs))
+		diffs, err := r.verifyRepo(targetPlatform, pair, refsOnly)
+		result.Add(pair.source.DisplayURL(), pair.target.DisplayURL(), diffs, err)
+		switch {
+		case err != nil:
+			logx.Error("verify %s: %v", pair.source.DisplayURL(), err)
+		case len(diffs) > 0:
+			logx.Warn("%s has %d difference(s) from %s", pair.target.DisplayURL(), len(diffs), pair.source.DisplayURL())
+			err = fmt.Errorf("%d difference(s) from source", len(diffs))
+		default:
+			logx.Info("%s matches %s (took %s)", pair.target.DisplayURL(), pair.source.DisplayURL(), time.Since(start))
+		}
+		report.Add(pair.source.DisplayURL(), pair.target.DisplayURL(), start, err)
+	}
+	return result, nil
+}
+
+// verifyPairs returns the repositories to compare.
+func (r *Repo) verifyPairs(targetURL *url.URL, targetPlatform platforms.IPlatform, targetCredential *credential.Credential) ([]verifyPair, error) {
+	if strings.HasSuffix(r.repoURL.Path, ".git") {
+		source := *r.credential
+		target := *targetCredential
+		if err := target.SetCloneByRepoName(path.Base(r.repoURL.Path)); err != nil {
+			return nil, err
+		}
+		return []verifyPair{{source: &source, target: &target}}, nil
+	}
+	nameRule, err := flags.GetRepoNameRule(r.cmd)
+	if err != nil {
+		return nil, err
+	}
+	sourceOrg, err := x.RepoURLParseOrgName(r.repoURL)
+	if err != nil {
+		return nil, err
+	}
+	targetOrg, err := x.RepoURLParseOrgName(targetURL)
+	if err != nil {
+		return nil, err
+	}
+	_, nested := targetPlatform.(platforms.IPlatformSubOrgs)
+	nested = nested && flags.GetRecursive(r.cmd) && targetOrg != ""
+	repos, err := r.ListRepo()
+	if err != nil {
+		return nil, err
+	}
+	if filter := flags.GetFilter(r.cmd); len(filter) > 0 {
+		repos = slices.DeleteFunc(repos, func(repo *platforms.RepoInfo) bool {
+			return !x.RepoMatch(repo.FullName, filter)
+		})
+		logx.Info("%d repositories match filter %v", len(repos), filter)
+	}
+	targets, err := r.resolveSyncTargets(repos, nameRule, sourceOrg, nested)
+	if err != nil {
+		return nil, err
+	}
+	pairs := make([]verifyPair, 0, len(repos))
+	for _, repo := range repos {
+		target, err := targets[repo.FullName].credential(targetCredential)
+		if err != nil {
+			return nil, err
+		}
+		source := *r.credential
+		source.CloneURL = repo.CloneURL
+		pairs = append(pairs, verifyPair{source: &source, target: target})
+	}
+	return pairs, nil
+}
+
+// verifyRepo compares the refs and releases of a single repository pair.
+func (r *Repo) verifyRepo(targetPlatform platforms.IPlatform, pair verifyPair, refsOnly bool) ([]drift.Diff, error) {
+	sourceRefs, err := gitx.ListRefs(pair.source)
+	if err != nil {
+		return nil, fmt.Errorf("list source refs: %w", err)
+	}
+	targetFullName, err := pair.target.GetFullName()
+	if err != nil {
+		return nil, err
+	}
+	detail, err := targetPlatform.GetRepoDetail(r.ctx, targetFullName)
+	switch {
+	case platforms.IsNotFound(err), err == nil && detail.ID == 0:
+		return []drift.Diff{{Kind: drift.KindMissingRepo}}, nil
+	case err != nil:
+		return nil, fmt.Errorf("get target repository %s: %w", targetFullName, err)
+	}
+	targetRefs, err := gitx.ListRefs(pair.target)
+	if err != nil {
+		return nil, fmt.Errorf("list target refs: %w", err)
+	}
+	diffs := drift.CompareRefs(sourceRefs, targetRefs)
+	if refsOnly {
+		return diffs, nil
+	}
+	sourceFullName, err := pair.source.GetFullName()
+	if err != nil {
+		return nil, err
+	}
+	var tags []string
+	for name := range sourceRefs {
+		if tag, ok := strings.CutPrefix(name, "refs/tags/"); ok {
+			tags = append(tags, tag)
+		}
+	}
+	slices.Sort(tags)
+	for _, tag := range tags {
+		// 源仓库没有对应 Release 的标签不需要比较
+		sourceRelease, err := r.platform.GetTagReleaseInfo(r.ctx, sourceFullName, tag)
+		switch {
+		case platforms.IsNotFound(err), err == nil && sourceRelease == nil:
+			continue
+		case err != nil:
+			diffs = append(diffs, drift.Diff{Kind: drift.KindError, Name: tag, Target: fmt.Sprintf("get source release: %v", err)})
+			continue
+		}
+		targetRelease, err := targetPlatform.GetTagReleaseInfo(r.ctx, targetFullName, tag)
+		switch {
+		case platforms.IsNotFound(err), err == nil && targetRelease == nil:
+			diffs = append(diffs, drift.Diff{Kind: drift.KindMissingRelease, Name: tag})
+			continue
+		case err != nil:
+			diffs = append(diffs, drift.Diff{Kind: drift.KindError, Name: tag, Target: fmt.Sprintf("get target release: %v", err)})
+			continue
+		}
+		diffs = append(diffs, drift.CompareAssets(tag, sourceRelease.Assets, targetRelease.Assets)...)
+	}
+	return diffs, nil
+}
@@ -10,6 +10,8 @@ const (
 	FlagsWorkspace = "workspace"
 	FlagsPlatforms = "platform"
 	FlagsEnvFile   = "env"
+	FlagsReport    = "report"
+	FlagsMetrics   = "metrics-file"
 
 	FlagsFormUsername   = "username"
 	FlagsFormPassword   = "password"
@@ -47,6 +49,14 @@ func GlobalFlags() []cli.Flag {
 			Usage: "Your secret sauce: path to the .env file",
 			Value: ".env", // default env file
 		},
+		&cli.StringFlag{
+			Name:  FlagsReport,
+			Usage: "Write a JSON report of per-repository results to this file",
+		},
+		&cli.StringFlag{
+			Name:  FlagsMetrics,
+			Usage: "Write Prometheus metrics to this file when done (for the node_exporter textfile collector)",
+		},
 		&cli.StringFlag{
 			Name:    FlagsFormUsername,
 			Usage:   "Username for authenticating with the source repository",
@@ -84,3 +94,13 @@ func GlobalFlags() []cli.Flag {
 func GetWorkspace(cmd *cli.Command) string {
 	return cmd.String(FlagsWorkspace)
 }
+
+// GetReport returns the path of the per-repository result report, empty when disabled.
+func GetReport(cmd *cli.Command) string {
+	return cmd.String(FlagsReport)
+}
+
+// GetMetricsFile returns the path of the Prometheus textfile, empty when disabled.
+func GetMetricsFile(cmd *cli.Command) string {
+	return cmd.String(FlagsMetrics)
+}
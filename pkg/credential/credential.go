@@ -17,6 +17,8 @@ type WorkspaceCategory string
 const (
 	WorkspaceCategoryGit      WorkspaceCategory = "git"      // git 仓库
 	WorkspaceCategoryReleases WorkspaceCategory = "releases" // release 文件
+	WorkspaceCategoryPulls    WorkspaceCategory = "pulls"    // 合并请求引用镜像
+	WorkspaceCategorySync     WorkspaceCategory = "sync"     // 双向同步使用的裸仓库
 )
 
 type ICredential interface {
@@ -35,6 +37,11 @@ type Credential struct {
 	CloneURL string // 仓库的克隆地址（HTTPS URL），用于 git clone
 }
 
+// DisplayURL 返回隐藏了用户名和密码的克隆地址，用于日志和同步结果
+func (c *Credential) DisplayURL() string {
+	return x.RedactURLs(c.CloneURL)
+}
+
 func (c *Credential) GetGitAuth() transport.AuthMethod {
 	pwd := c.Password
 	if pwd == "" {
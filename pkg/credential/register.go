@@ -41,6 +41,10 @@ func GetCredential(repo *url.URL, username, password, token string, readEnv bool
 		c.Username = username
 		c.Password = password
 		c.Token = token
+		// 地址中的密码通常为访问令牌，未单独指定令牌时同时用于调用平台接口
+		if c.Token == "" {
+			c.Token = password
+		}
 		return c, nil
 	}
 
@@ -0,0 +1,188 @@
+package drift
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	KindMissingRepo    = "missing-repo"    // 目标仓库不存在
+	KindMissingRef     = "missing-ref"     // 目标缺少源仓库的分支或标签
+	KindChangedRef     = "changed-ref"     // 分支或标签在两端指向不同的提交
+	KindExtraRef       = "extra-ref"       // 目标存在源仓库没有的分支或标签
+	KindMissingRelease = "missing-release" // 目标缺少源仓库的 Release
+	KindMissingAsset   = "missing-asset"   // 目标 Release 缺少附件
+	KindExtraAsset     = "extra-asset"     // 目标 Release 多出的附件
+	KindAssetSize      = "asset-size"      // 附件大小不同
+	KindError          = "error"           // 无法比较，例如无权访问源仓库
+)
+
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+)
+
+// Diff 为源仓库与目标仓库之间的一处差异
+type Diff struct {
+	Repo       string `json:"repo"`             // 源仓库地址
+	TargetRepo string `json:"target_repo"`      // 目标仓库地址
+	Kind       string `json:"kind"`             // 差异类型，见 Kind 常量
+	Name       string `json:"name,omitempty"`   // 引用名、标签名或 "标签/附件名"
+	Source     string `json:"source,omitempty"` // 源端的值：提交哈希或附件大小
+	Target     string `json:"target,omitempty"` // 目标端的值，KindError 时为错误信息
+}
+
+// Result 为一次比较的结果
+type Result struct {
+	Repos       int    `json:"repos"`       // 比较的仓库数
+	Drifted     int    `json:"drifted"`     // 存在差异的仓库数
+	Failed      int    `json:"failed"`      // 无法比较的仓库数
+	Differences []Diff `json:"differences"` // 所有差异
+}
+
+// NewResult 创建空的比较结果
+func NewResult() *Result {
+	return &Result{Differences: []Diff{}}
+}
+
+// Add 记录一个仓库的比较结果，err 不为 nil 时记录为 KindError
+// diffs 中包含 KindError（例如部分 Release 无法读取）时同样计为无法比较
+func (r *Result) Add(repo, targetRepo string, diffs []Diff, err error) {
+	r.Repos++
+	if err != nil {
+		r.Failed++
+		diffs = []Diff{{Kind: KindError, Target: err.Error()}}
+	} else if slices.ContainsFunc(diffs, func(d Diff) bool { return d.Kind == KindError }) {
+		r.Failed++
+	} else if len(diffs) > 0 {
+		r.Drifted++
+	}
+	for _, diff := range diffs {
+		diff.Repo = repo
+		diff.TargetRepo = targetRepo
+		r.Differences = append(r.Differences, diff)
+	}
+}
+
+// HasDrift 判断是否存在差异或无法比较的仓库
+func (r *Result) HasDrift() bool {
+	return r.Drifted > 0 || r.Failed > 0
+}
+
+// CompareRefs 比较两端的分支和标签，参数为完整引用名到哈希的映射
+func CompareRefs(source, target map[string]string) []Diff {
+	var diffs []Diff
+	for _, name := range sortedKeys(source) {
+		targetHash, ok := target[name]
+		switch {
+		case !ok:
+			diffs = append(diffs, Diff{Kind: KindMissingRef, Name: name, Source: source[name]})
+		case targetHash != source[name]:
+			diffs = append(diffs, Diff{Kind: KindChangedRef, Name: name, Source: source[name], Target: targetHash})
+		}
+	}
+	for _, name := range sortedKeys(target) {
+		if _, ok := source[name]; !ok {
+			diffs = append(diffs, Diff{Kind: KindExtraRef, Name: name, Target: target[name]})
+		}
+	}
+	return diffs
+}
+
+// CompareAssets 比较同一标签下两端 Release 的附件名称和大小，任一端平台不提供大小时不比较大小
+func CompareAssets(tag string, source, target []*platforms.AssetInfo) []Diff {
+	targetAssets := make(map[string]*platforms.AssetInfo, len(target))
+	for _, asset := range target {
+		targetAssets[asset.Name] = asset
+	}
+	sourceAssets := make(map[string]bool, len(source))
+	var diffs []Diff
+	for _, asset := range source {
+		sourceAssets[asset.Name] = true
+		name := tag + "/" + asset.Name
+		targetAsset, ok := targetAssets[asset.Name]
+		switch {
+		case !ok:
+			diffs = append(diffs, Diff{Kind: KindMissingAsset, Name: name, Source: sizeString(asset.Size)})
+		case asset.Size != platforms.UnknownSize && targetAsset.Size != platforms.UnknownSize && asset.Size != targetAsset.Size:
+			diffs = append(diffs, Diff{Kind: KindAssetSize, Name: name, Source: sizeString(asset.Size), Target: sizeString(targetAsset.Size)})
+		}
+	}
+	for _, asset := range target {
+		if !sourceAssets[asset.Name] {
+			diffs = append(diffs, Diff{Kind: KindExtraAsset, Name: tag + "/" + asset.Name, Target: sizeString(asset.Size)})
+		}
+	}
+	return diffs
+}
+
+// Write 以 format 格式将结果写入 w
+func (r *Result) Write(w io.Writer, format string) error {
+	switch format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(r)
+	case FormatTable:
+		return r.writeTable(w)
+	default:
+		return fmt.Errorf("unsupported format %q, expected %s or %s", format, FormatTable, FormatJSON)
+	}
+}
+
+// writeTable 以表格形式输出差异，哈希缩短为 12 位
+func (r *Result) writeTable(w io.Writer) error {
+	if len(r.Differences) > 0 {
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(tw, "REPO\tTARGET REPO\tKIND\tNAME\tSOURCE\tTARGET")
+		for _, diff := range r.Differences {
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+				diff.Repo, diff.TargetRepo, diff.Kind, dash(diff.Name), dash(shortHash(diff.Source)), dash(shortHash(diff.Target)))
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+	if !r.HasDrift() {
+		_, err := fmt.Fprintf(w, "No drift: %d repositories match\n", r.Repos)
+		return err
+	}
+	_, err := fmt.Fprintf(w, "%d of %d repositories drifted, %d could not be compared\n", r.Drifted, r.Repos, r.Failed)
+	return err
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func sizeString(size int64) string {
+	if size == platforms.UnknownSize {
+		return ""
+	}
+	return strconv.FormatInt(size, 10)
+}
+
+func shortHash(s string) string {
+	if len(s) == 40 && strings.Trim(s, "0123456789abcdef") == "" {
+		return s[:12]
+	}
+	return s
+}
+
+func dash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,140 @@
+package drift
+
+import (
+	"bytes"
+	"errors"
+	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCompareRefs(t *testing.T) {
+	tests := []struct {
+		name   string
+		source map[string]string
+		target map[string]string
+		want   []Diff
+	}{
+		{
+			name:   "equal",
+			source: map[string]string{"refs/heads/main": "a1", "refs/tags/v1": "b1"},
+			target: map[string]string{"refs/heads/main": "a1", "refs/tags/v1": "b1"},
+		},
+		{
+			name:   "empty target",
+			source: map[string]string{"refs/tags/v1": "b1", "refs/heads/main": "a1"},
+			want: []Diff{
+				{Kind: KindMissingRef, Name: "refs/heads/main", Source: "a1"},
+				{Kind: KindMissingRef, Name: "refs/tags/v1", Source: "b1"},
+			},
+		},
+		{
+			name:   "changed and extra",
+			source: map[string]string{"refs/heads/main": "a1"},
+			target: map[string]string{"refs/heads/main": "a2", "refs/heads/dev": "c1"},
+			want: []Diff{
+				{Kind: KindChangedRef, Name: "refs/heads/main", Source: "a1", Target: "a2"},
+				{Kind: KindExtraRef, Name: "refs/heads/dev", Target: "c1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CompareRefs(tt.source, tt.target); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CompareRefs() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareAssets(t *testing.T) {
+	asset := func(name string, size int64) *platforms.AssetInfo {
+		return &platforms.AssetInfo{Name: name, Size: size}
+	}
+	tests := []struct {
+		name   string
+		source []*platforms.AssetInfo
+		target []*platforms.AssetInfo
+		want   []Diff
+	}{
+		{
+			name:   "equal",
+			source: []*platforms.AssetInfo{asset("app.tar.gz", 10)},
+			target: []*platforms.AssetInfo{asset("app.tar.gz", 10)},
+		},
+		{
+			name:   "size differs",
+			source: []*platforms.AssetInfo{asset("app.tar.gz", 10)},
+			target: []*platforms.AssetInfo{asset("app.tar.gz", 12)},
+			want:   []Diff{{Kind: KindAssetSize, Name: "v1/app.tar.gz", Source: "10", Target: "12"}},
+		},
+		{
+			name:   "unknown size is not compared",
+			source: []*platforms.AssetInfo{asset("app.tar.gz", 10), asset("app.zip", platforms.UnknownSize)},
+			target: []*platforms.AssetInfo{asset("app.tar.gz", platforms.UnknownSize), asset("app.zip", 20)},
+		},
+		{
+			name:   "missing and extra",
+			source: []*platforms.AssetInfo{asset("app.tar.gz", 10), asset("app.zip", platforms.UnknownSize)},
+			target: []*platforms.AssetInfo{asset("app.tar.gz", 10), asset("notes.txt", 3)},
+			want: []Diff{
+				{Kind: KindMissingAsset, Name: "v1/app.zip"},
+				{Kind: KindExtraAsset, Name: "v1/notes.txt", Target: "3"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CompareAssets("v1", tt.source, tt.target); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CompareAssets() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResultAdd(t *testing.T) {
+	r := NewResult()
+	r.Add("https://github.com/org/a.git", "https://gitea.com/org/a.git", nil, nil)
+	r.Add("https://github.com/org/b.git", "https://gitea.com/org/b.git", []Diff{{Kind: KindMissingRef, Name: "refs/heads/main"}}, nil)
+	r.Add("https://github.com/org/c.git", "https://gitea.com/org/c.git", []Diff{{Kind: KindError, Name: "v1", Target: "forbidden"}}, nil)
+	r.Add("https://github.com/org/d.git", "https://gitea.com/org/d.git", nil, errors.New("not found"))
+	if r.Repos != 4 || r.Drifted != 1 || r.Failed != 2 {
+		t.Errorf("Repos, Drifted, Failed = %d, %d, %d, want 4, 1, 2", r.Repos, r.Drifted, r.Failed)
+	}
+	want := []Diff{
+		{Repo: "https://github.com/org/b.git", TargetRepo: "https://gitea.com/org/b.git", Kind: KindMissingRef, Name: "refs/heads/main"},
+		{Repo: "https://github.com/org/c.git", TargetRepo: "https://gitea.com/org/c.git", Kind: KindError, Name: "v1", Target: "forbidden"},
+		{Repo: "https://github.com/org/d.git", TargetRepo: "https://gitea.com/org/d.git", Kind: KindError, Target: "not found"},
+	}
+	if !reflect.DeepEqual(r.Differences, want) {
+		t.Errorf("Differences = %+v, want %+v", r.Differences, want)
+	}
+	if !r.HasDrift() {
+		t.Error("HasDrift() = false, want true")
+	}
+}
+
+func TestResultWrite(t *testing.T) {
+	r := NewResult()
+	r.Add("src", "dst", nil, nil)
+	var buf bytes.Buffer
+	if err := r.Write(&buf, FormatTable); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if got := buf.String(); got != "No drift: 1 repositories match\n" {
+		t.Errorf("Write(table) = %q", got)
+	}
+
+	r.Add("src2", "dst2", []Diff{{Kind: KindChangedRef, Name: "refs/heads/main", Source: strings.Repeat("a", 40), Target: strings.Repeat("b", 40)}}, nil)
+	buf.Reset()
+	if err := r.Write(&buf, FormatTable); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if got := buf.String(); !strings.Contains(got, strings.Repeat("a", 12)+"  ") || strings.Contains(got, strings.Repeat("a", 13)) {
+		t.Errorf("Write(table) did not shorten hashes:\n%s", got)
+	}
+	if err := r.Write(&buf, "yaml"); err == nil {
+		t.Error("Write(yaml) error = nil, want unsupported format")
+	}
+}
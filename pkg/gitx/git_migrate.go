@@ -7,11 +7,19 @@ import (
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
 )
 
 type GitMigrate struct {
 	form   *credential.Credential
 	target *credential.Credential
+	head   string // 源仓库 HEAD 指向的分支（Clone 时从远程引用公告中获取）
+
+	pushMode  string   // 推送模式，为空时等同 PushForce
+	protected []string // 只允许快进的分支
+
+	atomic  bool              // 原子推送，所有引用全部更新或全部不更新
+	options map[string]string // 推送选项（git push -o）
 }
 
 // NewGitMigrateDouble 创建 GitMigrate 实例并初始化认证
@@ -33,47 +41,34 @@ func (m *GitMigrate) WithForm(credential *credential.Credential) {
 func (m *GitMigrate) WithTarget(credential *credential.Credential) {
 	m.target = credential
 }
+
+// Head 返回源仓库 HEAD 指向的分支名，需在 Clone 之后调用，未知时返回空字符串
+func (m *GitMigrate) Head() string {
+	return m.head
+}
+
 func (m *GitMigrate) Push(path string) error {
 	// 打开本地仓库
 	repo, err := git.PlainOpen(path)
 	if err != nil {
 		return fmt.Errorf("failed target open repo: %w", err)
 	}
-	// 设置远程 "target"
+	// 设置远程 "target"，复用的工作区可能推送到不同的目标，已存在时先删除
+	if err := repo.DeleteRemote("target"); err != nil && !errors.Is(err, git.ErrRemoteNotFound) {
+		return fmt.Errorf("failed target delete remote: %w", err)
+	}
 	_, err = repo.CreateRemote(&config.RemoteConfig{
 		Name: "target",
 		URLs: []string{m.target.CloneURL},
 	})
-	if err != nil && err != git.ErrRemoteExists {
+	if err != nil {
 		return fmt.Errorf("failed target create remote: %w", err)
 	}
-	// 推送所有分支
-	err = repo.Push(&git.PushOptions{
-		RemoteName: "target",
-		RefSpecs: []config.RefSpec{
-			"+refs/heads/*:refs/heads/*", // 推送所有分支
-		},
-		Auth:  m.target.GetGitAuth(),
-		Force: true,
-	})
-	if err != nil && err != git.NoErrAlreadyUpToDate {
-		return fmt.Errorf("failed target push branches: %w", err)
-	}
-	// 推送所有 tag
-	err = repo.Push(&git.PushOptions{
-		RemoteName: "target",
-		RefSpecs: []config.RefSpec{
-			"+refs/tags/*:refs/tags/*", // 推送所有 tag
-		},
-		Auth: m.target.GetGitAuth(),
-	})
-	if err != nil {
-		if errors.Is(err, git.NoErrAlreadyUpToDate) {
-			return nil
-		}
-		return fmt.Errorf("failed target push tags: %w", err)
+	if err := m.checkPushCapabilities(); err != nil {
+		return err
 	}
-	return nil
+	// 根据目标现有的引用逐个推送，目标的引用列表同时用于判断能否安全更新和统计推送的引用数
+	return m.safePush(repo)
 }
 
 func (m *GitMigrate) Clone(path string, branches, tags []string) ([]string, []string, error) {
@@ -83,12 +78,10 @@ func (m *GitMigrate) Clone(path string, branches, tags []string) ([]string, []st
 		refSpecs       []config.RefSpec
 	)
 
-	// 如果没有指定分支或标签，从远程获取
-	if len(branches) == 0 || len(tags) == 0 {
-		rBranch, rTags, err = m.getRemoteBranchAndTag()
-		if err != nil {
-			return nil, nil, fmt.Errorf("failed target get remote branches and tags: %w", err)
-		}
+	// 从远程获取分支、标签以及 HEAD 指向的默认分支
+	rBranch, rTags, m.head, err = m.getRemoteBranchAndTag()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed target get remote branches and tags: %w", err)
 	}
 	if len(branches) == 0 {
 		branches = rBranch
@@ -119,8 +112,11 @@ func (m *GitMigrate) Clone(path string, branches, tags []string) ([]string, []st
 		return nil, nil, fmt.Errorf("no remote branches or tags specified")
 	}
 
-	// 初始化空仓库
-	repo, err := git.PlainInit(path, false)
+	// 复用已有的工作区仓库，只拉取增量对象；不存在时初始化空仓库
+	repo, err := git.PlainOpen(path)
+	if errors.Is(err, git.ErrRepositoryNotExists) {
+		repo, err = git.PlainInit(path, false)
+	}
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed target init repo: %w", err)
 	}
@@ -130,7 +126,7 @@ func (m *GitMigrate) Clone(path string, branches, tags []string) ([]string, []st
 		Name: "origin",
 		URLs: []string{m.form.CloneURL},
 	})
-	if err != nil {
+	if err != nil && err != git.ErrRemoteExists {
 		return nil, nil, fmt.Errorf("failed target create remote: %w", err)
 	}
 
@@ -144,14 +140,19 @@ func (m *GitMigrate) Clone(path string, branches, tags []string) ([]string, []st
 		return nil, nil, fmt.Errorf("failed target fetch refs: %w", err)
 	}
 
+	// 删除上次同步遗留、本次不再同步的本地分支和标签
+	if err := pruneRefs(repo, branches, tags); err != nil {
+		return nil, nil, fmt.Errorf("failed target prune stale refs: %w", err)
+	}
+
 	// 获取工作区
 	w, err := repo.Worktree()
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed target get worktree: %w", err)
 	}
 
-	// 遍历所有分支，创建本地分支并 checkout
-	for _, branch := range branches {
+	// 遍历所有分支，将本地分支指向远程提交并 checkout，默认分支最后 checkout 使本地 HEAD 与源仓库一致
+	for _, branch := range headLast(branches, m.head) {
 		if branch == "" {
 			continue
 		}
@@ -160,12 +161,14 @@ func (m *GitMigrate) Clone(path string, branches, tags []string) ([]string, []st
 		if err != nil {
 			return nil, nil, fmt.Errorf("remote branch not found: %s", branch)
 		}
+		branchRef := plumbing.NewBranchReferenceName(branch)
+		if err := repo.Storer.SetReference(plumbing.NewHashReference(branchRef, ref.Hash())); err != nil {
+			return nil, nil, fmt.Errorf("failed target update branch %s: %w", branch, err)
+		}
 
 		err = w.Checkout(&git.CheckoutOptions{
-			Branch: plumbing.NewBranchReferenceName(branch),
-			Create: true,
+			Branch: branchRef,
 			Force:  true,
-			Hash:   ref.Hash(),
 		})
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed target checkout branch %s: %w", branch, err)
@@ -193,8 +196,8 @@ func (m *GitMigrate) Clone(path string, branches, tags []string) ([]string, []st
 	return branches, tags, nil
 }
 
-// getRemoteBranches 获取远程分支列表
-func (m *GitMigrate) getRemoteBranchAndTag() (branches []string, tags []string, err error) {
+// getRemoteBranchAndTag 获取远程分支、标签列表以及 HEAD 指向的分支
+func (m *GitMigrate) getRemoteBranchAndTag() (branches []string, tags []string, head string, err error) {
 	remote := git.NewRemote(nil, &config.RemoteConfig{
 		Name: "origin",
 		URLs: []string{m.form.CloneURL},
@@ -204,9 +207,12 @@ func (m *GitMigrate) getRemoteBranchAndTag() (branches []string, tags []string,
 	}
 	refs, err := remote.List(listOpts)
 	if err != nil {
-		return []string{}, []string{}, err
+		return []string{}, []string{}, "", err
 	}
 	for _, ref := range refs {
+		if ref.Name() == plumbing.HEAD && ref.Type() == plumbing.SymbolicReference && ref.Target().IsBranch() {
+			head = ref.Target().Short()
+		}
 		if ref.Name().IsBranch() {
 			branches = append(branches, ref.Name().Short())
 		}
@@ -214,5 +220,126 @@ func (m *GitMigrate) getRemoteBranchAndTag() (branches []string, tags []string,
 			tags = append(tags, ref.Name().Short())
 		}
 	}
-	return branches, tags, nil
+	return branches, tags, head, nil
+}
+
+// pruneRefs 删除不在 branches 和 tags 中的本地分支和标签，使复用的工作区与全新克隆的结果一致
+func pruneRefs(repo *git.Repository, branches, tags []string) error {
+	keep := make(map[plumbing.ReferenceName]bool, len(branches)+len(tags))
+	for _, branch := range branches {
+		keep[plumbing.NewBranchReferenceName(branch)] = true
+	}
+	for _, tag := range tags {
+		keep[plumbing.NewTagReferenceName(tag)] = true
+	}
+	refs, err := repo.References()
+	if err != nil {
+		return err
+	}
+	var stale []plumbing.ReferenceName
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		if (ref.Name().IsBranch() || ref.Name().IsTag()) && !keep[ref.Name()] {
+			stale = append(stale, ref.Name())
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, name := range stale {
+		if err := repo.Storer.RemoveReference(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// headLast 返回将 head 移到末尾后的分支列表，head 不在列表中时保持原顺序
+func headLast(branches []string, head string) []string {
+	ordered := make([]string, 0, len(branches))
+	found := false
+	for _, branch := range branches {
+		if branch == head {
+			found = true
+			continue
+		}
+		ordered = append(ordered, branch)
+	}
+	if found {
+		ordered = append(ordered, head)
+	}
+	return ordered
+}
+
+// MirrorRef 将源仓库中的任意引用（例如 "refs/pull/12/head"）以分支 branch 的形式推送到目标仓库
+// path 下不存在仓库时会初始化一个裸仓库，重复调用只传输增量对象
+func (m *GitMigrate) MirrorRef(path, ref, branch string) error {
+	repo, err := git.PlainOpen(path)
+	if errors.Is(err, git.ErrRepositoryNotExists) {
+		repo, err = git.PlainInit(path, true)
+	}
+	if err != nil {
+		return fmt.Errorf("failed open mirror repo: %w", err)
+	}
+	for name, cloneURL := range map[string]string{"origin": m.form.CloneURL, "target": m.target.CloneURL} {
+		_, err = repo.CreateRemote(&config.RemoteConfig{
+			Name: name,
+			URLs: []string{cloneURL},
+		})
+		if err != nil && err != git.ErrRemoteExists {
+			return fmt.Errorf("failed create remote %s: %w", name, err)
+		}
+	}
+	branchRef := plumbing.NewBranchReferenceName(branch)
+	err = repo.Fetch(&git.FetchOptions{
+		RemoteName: "origin",
+		RefSpecs: []config.RefSpec{
+			config.RefSpec(fmt.Sprintf("+%s:%s", ref, branchRef)),
+		},
+		Auth: m.form.GetGitAuth(),
+	})
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return fmt.Errorf("failed fetch %s: %w", ref, err)
+	}
+	err = repo.Push(&git.PushOptions{
+		RemoteName: "target",
+		RefSpecs: []config.RefSpec{
+			config.RefSpec(fmt.Sprintf("+%s:%s", branchRef, branchRef)),
+		},
+		Auth:  m.target.GetGitAuth(),
+		Force: true,
+	})
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return fmt.Errorf("failed target push %s: %w", branch, err)
+	}
+	return nil
+}
+
+// HasRefs 判断源仓库是否存在且至少有一个分支或标签，用于探测 Wiki 等可选仓库
+func (m *GitMigrate) HasRefs() bool {
+	branches, tags, _, err := m.getRemoteBranchAndTag()
+	return err == nil && len(branches)+len(tags) > 0
+}
+
+// ListRefs 列出远程仓库的分支和标签及其哈希，键为完整引用名（如 refs/heads/main），不下载任何对象
+// 空仓库返回空结果
+func ListRefs(cred *credential.Credential) (map[string]string, error) {
+	remote := git.NewRemote(nil, &config.RemoteConfig{
+		Name: "origin",
+		URLs: []string{cred.CloneURL},
+	})
+	refs, err := remote.List(&git.ListOptions{Auth: cred.GetGitAuth()})
+	if errors.Is(err, transport.ErrEmptyRemoteRepository) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]string, len(refs))
+	for _, ref := range refs {
+		if ref.Type() == plumbing.HashReference && (ref.Name().IsBranch() || ref.Name().IsTag()) {
+			result[ref.Name().String()] = ref.Hash().String()
+		}
+	}
+	return result, nil
 }
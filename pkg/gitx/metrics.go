@@ -0,0 +1,28 @@
+package gitx
+
+import (
+	"github.com/chihqiang/mpgrm/pkg/httpx"
+	"github.com/chihqiang/mpgrm/pkg/metrics"
+	"github.com/go-git/go-git/v5/plumbing/transport/client"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"net/url"
+)
+
+// 通过 httpx 的客户端进行 git 传输，使克隆和推送的流量计入指标
+func init() {
+	gitClient := githttp.NewClient(httpx.Client())
+	client.InstallProtocol("http", gitClient)
+	client.InstallProtocol("https", gitClient)
+}
+
+// recordPushed 记录推送到 cloneURL 的引用数
+func recordPushed(cloneURL, kind string, n int) {
+	if n == 0 {
+		return
+	}
+	host := cloneURL
+	if u, err := url.Parse(cloneURL); err == nil {
+		host = u.Host
+	}
+	metrics.RefsPushed.WithLabelValues(host, kind).Add(float64(n))
+}
@@ -0,0 +1,68 @@
+package gitx
+
+import (
+	"errors"
+	"fmt"
+	"github.com/chihqiang/mpgrm/pkg/logx"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing/protocol/packp/capability"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/client"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// ErrAtomicUnsupported 表示请求了原子推送，但目标服务器不支持
+var ErrAtomicUnsupported = errors.New("target does not support atomic push")
+
+// WithPushOptions 设置原子推送和推送选项（git push -o），服务器不支持推送选项时忽略并输出警告
+func (m *GitMigrate) WithPushOptions(atomic bool, options map[string]string) {
+	m.atomic = atomic
+	m.options = options
+}
+
+// pushOptions 返回推送到 target 远程的选项
+func (m *GitMigrate) pushOptions(refSpecs ...config.RefSpec) *git.PushOptions {
+	return &git.PushOptions{
+		RemoteName: "target",
+		RefSpecs:   refSpecs,
+		Auth:       m.target.GetGitAuth(),
+		Atomic:     m.atomic,
+		Options:    m.options,
+	}
+}
+
+// checkPushCapabilities 在请求原子推送或推送选项时检查目标是否支持
+// 不支持原子推送时返回错误，避免退化为逐个引用更新；不支持推送选项时只输出警告
+func (m *GitMigrate) checkPushCapabilities() error {
+	if !m.atomic && len(m.options) == 0 {
+		return nil
+	}
+	endpoint, err := transport.NewEndpoint(m.target.CloneURL)
+	if err != nil {
+		return err
+	}
+	c, err := client.NewClient(endpoint)
+	if err != nil {
+		return err
+	}
+	session, err := c.NewReceivePackSession(endpoint, m.target.GetGitAuth())
+	if err != nil {
+		return fmt.Errorf("failed target connect: %w", err)
+	}
+	defer func() { _ = session.Close() }()
+	refs, err := session.AdvertisedReferences()
+	if err != nil {
+		return fmt.Errorf("failed target get capabilities: %w", err)
+	}
+	target := m.target.DisplayURL()
+	if m.atomic && !refs.Capabilities.Supports(capability.Atomic) {
+		return fmt.Errorf("%w: %s", ErrAtomicUnsupported, target)
+	}
+	if len(m.options) > 0 && !refs.Capabilities.Supports(capability.PushOptions) {
+		logx.Warn("target %s does not support push options, ignoring %s", target, strings.Join(slices.Sorted(maps.Keys(m.options)), ", "))
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+package gitx
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"path"
+	"slices"
+	"strings"
+)
+
+// 推送模式
+const (
+	PushForce          = "force"            // 强制覆盖目标的分支，默认行为
+	PushNoForce        = "no-force"         // 只快进目标的分支，不能快进时拒绝
+	PushForceWithLease = "force-with-lease" // 目标分支仍停留在上次推送的提交时才允许覆盖
+)
+
+// RejectedRef 为推送时被拒绝的分支或标签
+type RejectedRef struct {
+	Ref    string // 完整引用名
+	Source string // 要推送的哈希
+	Target string // 目标当前的哈希
+	Reason string // 拒绝原因
+}
+
+// RejectedError 表示部分引用因不能安全更新而未推送，其余引用已正常推送
+type RejectedError struct {
+	Refs []RejectedRef
+}
+
+func (e *RejectedError) Error() string {
+	refs := make([]string, 0, len(e.Refs))
+	for _, ref := range e.Refs {
+		refs = append(refs, fmt.Sprintf("%s (%s)", ref.Ref, ref.Reason))
+	}
+	return fmt.Sprintf("%d ref(s) rejected: %s", len(e.Refs), strings.Join(refs, ", "))
+}
+
+// WithPushMode 设置推送模式和受保护分支，受保护分支在任何模式下都只快进，支持 path.Match 通配符
+func (m *GitMigrate) WithPushMode(mode string, protected []string) {
+	m.pushMode = mode
+	m.protected = protected
+}
+
+// safePush 逐个比较本地和目标的引用后推送，强制模式下非快进的引用强制更新，其它模式下不能安全更新的引用记录到 RejectedError 中
+func (m *GitMigrate) safePush(repo *git.Repository) error {
+	remote, err := repo.Remote("target")
+	if err != nil {
+		return err
+	}
+	remoteRefs := make(map[plumbing.ReferenceName]plumbing.Hash)
+	refs, err := remote.List(&git.ListOptions{Auth: m.target.GetGitAuth()})
+	if err != nil && !errors.Is(err, transport.ErrEmptyRemoteRepository) {
+		return fmt.Errorf("failed target list refs: %w", err)
+	}
+	for _, ref := range refs {
+		if ref.Type() == plumbing.HashReference {
+			remoteRefs[ref.Name()] = ref.Hash()
+		}
+	}
+	localRefs, err := localBranchesAndTags(repo)
+	if err != nil {
+		return err
+	}
+	lease := leaseNamespace(m.target.CloneURL)
+
+	// 所有引用在同一次推送中更新，目标的默认分支在推送后通过 API 设置
+	var refSpecs, require []config.RefSpec
+	var rejected []RejectedRef
+	var branches, tags int
+	for _, ref := range localRefs {
+		name := ref.Name()
+		old, exists := remoteRefs[name]
+		if exists && old == ref.Hash() {
+			continue
+		}
+		refSpec := config.RefSpec(fmt.Sprintf("%s:%s", name, name))
+		if exists {
+			reason, force := m.checkUpdate(repo, lease, name, old, ref.Hash())
+			if reason != "" {
+				rejected = append(rejected, RejectedRef{Ref: name.String(), Source: ref.Hash().String(), Target: old.String(), Reason: reason})
+				continue
+			}
+			if force {
+				refSpec = "+" + refSpec
+			}
+			// 推送时目标仍须停留在比较时的提交，期间有新的推送则整个推送失败而不是覆盖
+			require = append(require, config.RefSpec(fmt.Sprintf("%s:%s", old, name)))
+		}
+		if name.IsTag() {
+			tags++
+		} else {
+			branches++
+		}
+		refSpecs = append(refSpecs, refSpec)
+	}
+	if len(refSpecs) > 0 {
+		options := m.pushOptions(refSpecs...)
+		options.RequireRemoteRefs = require
+		err = repo.Push(options)
+		if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+			return fmt.Errorf("failed target push: %w", err)
+		}
+	}
+	recordPushed(m.target.CloneURL, "branch", branches)
+	recordPushed(m.target.CloneURL, "tag", tags)
+	if err := recordLeases(repo, lease, rejected); err != nil {
+		return err
+	}
+	if len(rejected) > 0 {
+		return &RejectedError{Refs: rejected}
+	}
+	return nil
+}
+
+// checkUpdate 判断目标已存在的引用能否从 old 更新为 new，不能更新时返回原因，需要强制更新时 force 为 true
+func (m *GitMigrate) checkUpdate(repo *git.Repository, lease string, name plumbing.ReferenceName, old, new plumbing.Hash) (reason string, force bool) {
+	if name.IsTag() {
+		// 标签没有快进的概念，强制模式下直接覆盖，受保护分支不影响标签
+		if m.pushMode == PushNoForce || m.pushMode == PushForceWithLease {
+			return "tag exists on target with a different object", false
+		}
+		return "", true
+	}
+	if fastForward(repo, old, new) {
+		return "", false
+	}
+	if m.branchProtected(name.Short()) {
+		return "protected branch, non-fast-forward", false
+	}
+	switch m.pushMode {
+	case PushNoForce:
+		return "non-fast-forward", false
+	case PushForceWithLease:
+		leased, err := repo.Reference(plumbing.ReferenceName(lease+name.String()), true)
+		if err != nil {
+			return "non-fast-forward, no lease recorded for target", false
+		}
+		if leased.Hash() != old {
+			return "non-fast-forward, target changed since last push", false
+		}
+	}
+	return "", true
+}
+
+// branchProtected 判断分支是否在受保护列表中
+func (m *GitMigrate) branchProtected(branch string) bool {
+	for _, pattern := range m.protected {
+		if ok, _ := path.Match(pattern, branch); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// fastForward 判断 old 是否为 new 的祖先，本地没有 old 对象时说明目标有源仓库没有的提交
+func fastForward(repo *git.Repository, old, new plumbing.Hash) bool {
+	oldCommit, err := repo.CommitObject(old)
+	if err != nil {
+		return false
+	}
+	newCommit, err := repo.CommitObject(new)
+	if err != nil {
+		return false
+	}
+	ok, err := oldCommit.IsAncestor(newCommit)
+	return err == nil && ok
+}
+
+// localBranchesAndTags 返回本地所有分支和标签，按名称排序
+func localBranchesAndTags(repo *git.Repository) ([]*plumbing.Reference, error) {
+	iter, err := repo.References()
+	if err != nil {
+		return nil, err
+	}
+	var refs []*plumbing.Reference
+	err = iter.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() == plumbing.HashReference && (ref.Name().IsBranch() || ref.Name().IsTag()) {
+			refs = append(refs, ref)
+		}
+		return nil
+	})
+	slices.SortFunc(refs, func(a, b *plumbing.Reference) int {
+		return strings.Compare(a.Name().String(), b.Name().String())
+	})
+	return refs, err
+}
+
+// leaseNamespace 返回记录推送到 cloneURL 的分支提交的引用前缀，同一工作区可能推送到多个目标
+func leaseNamespace(cloneURL string) string {
+	sum := sha1.Sum([]byte(cloneURL))
+	return "refs/lease/" + hex.EncodeToString(sum[:8]) + "/"
+}
+
+// recordLeases 记录本次推送后目标各分支所在的提交，供 --force-with-lease 下次判断目标是否被他人更新
+// 被拒绝的分支保留原有记录
+func recordLeases(repo *git.Repository, lease string, rejected []RejectedRef) error {
+	refs, err := localBranchesAndTags(repo)
+	if err != nil {
+		return err
+	}
+	for _, ref := range refs {
+		if !ref.Name().IsBranch() || slices.ContainsFunc(rejected, func(r RejectedRef) bool { return r.Ref == ref.Name().String() }) {
+			continue
+		}
+		leaseRef := plumbing.NewHashReference(plumbing.ReferenceName(lease+ref.Name().String()), ref.Hash())
+		if err := repo.Storer.SetReference(leaseRef); err != nil {
+			return fmt.Errorf("failed record lease of %s: %w", ref.Name(), err)
+		}
+	}
+	return nil
+}
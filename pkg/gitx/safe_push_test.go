@@ -0,0 +1,262 @@
+package gitx
+
+import (
+	"errors"
+	"github.com/chihqiang/mpgrm/pkg/credential"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+// requireGit 本地仓库之间的传输需要 git-upload-pack 和 git-receive-pack
+func requireGit(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git command not found")
+	}
+}
+
+// newBareRepo 在临时目录中创建裸仓库
+func newBareRepo(t *testing.T, name string) (string, *git.Repository) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	repo, err := git.PlainInit(path, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path, repo
+}
+
+// writeCommits 在仓库中写入固定的提交，同样的提交在不同仓库中哈希相同
+// a 为根提交，b、c 都基于 a 且互相分叉，d 基于 b
+func writeCommits(t *testing.T, repo *git.Repository) map[string]plumbing.Hash {
+	t.Helper()
+	tree := repo.Storer.NewEncodedObject()
+	if err := (&object.Tree{}).Encode(tree); err != nil {
+		t.Fatal(err)
+	}
+	treeHash, err := repo.Storer.SetEncodedObject(tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	commits := make(map[string]plumbing.Hash)
+	write := func(name string, parents ...string) {
+		sig := object.Signature{Name: "mpgrm", Email: "mpgrm@example.com", When: time.Unix(1700000000, 0).UTC()}
+		commit := &object.Commit{Author: sig, Committer: sig, Message: name, TreeHash: treeHash}
+		for _, parent := range parents {
+			commit.ParentHashes = append(commit.ParentHashes, commits[parent])
+		}
+		obj := repo.Storer.NewEncodedObject()
+		if err := commit.Encode(obj); err != nil {
+			t.Fatal(err)
+		}
+		if commits[name], err = repo.Storer.SetEncodedObject(obj); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("a")
+	write("b", "a")
+	write("c", "a")
+	write("d", "b")
+	return commits
+}
+
+// setRefs 将 refs 中的引用指向对应的提交，reset 为 true 时先删除其它分支和标签
+func setRefs(t *testing.T, repo *git.Repository, commits map[string]plumbing.Hash, refs map[string]string, reset bool) {
+	t.Helper()
+	if reset {
+		existing, err := localBranchesAndTags(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, ref := range existing {
+			if err := repo.Storer.RemoveReference(ref.Name()); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	for name, commit := range refs {
+		if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(name), commits[commit])); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// readRefs 返回仓库的分支和标签，值为 commits 中的提交名称
+func readRefs(t *testing.T, path string, commits map[string]plumbing.Hash) map[string]string {
+	t.Helper()
+	repo, err := git.PlainOpen(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	refs, err := localBranchesAndTags(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := make(map[string]string, len(refs))
+	for _, ref := range refs {
+		for name, hash := range commits {
+			if hash == ref.Hash() {
+				result[ref.Name().String()] = name
+			}
+		}
+	}
+	return result
+}
+
+func TestSafePush(t *testing.T) {
+	requireGit(t)
+	tests := []struct {
+		name         string
+		mode         string
+		protected    []string
+		atomic       bool
+		options      map[string]string
+		initial      map[string]string // 首次强制推送到目标的引用
+		external     map[string]string // 首次推送后他人直接更新的目标引用
+		local        map[string]string // 本次推送的本地引用
+		wantRejected []string
+		wantTarget   map[string]string
+	}{
+		{
+			name:       "force overwrites diverged branch",
+			mode:       PushForce,
+			initial:    map[string]string{"refs/heads/main": "b"},
+			local:      map[string]string{"refs/heads/main": "c"},
+			wantTarget: map[string]string{"refs/heads/main": "c"},
+		},
+		{
+			name:       "no-force fast-forwards",
+			mode:       PushNoForce,
+			initial:    map[string]string{"refs/heads/main": "a"},
+			local:      map[string]string{"refs/heads/main": "b", "refs/heads/dev": "c"},
+			wantTarget: map[string]string{"refs/heads/main": "b", "refs/heads/dev": "c"},
+		},
+		{
+			name:         "no-force rejects non-fast-forward",
+			mode:         PushNoForce,
+			initial:      map[string]string{"refs/heads/main": "b", "refs/heads/dev": "a"},
+			local:        map[string]string{"refs/heads/main": "c", "refs/heads/dev": "b"},
+			wantRejected: []string{"refs/heads/main"},
+			wantTarget:   map[string]string{"refs/heads/main": "b", "refs/heads/dev": "b"},
+		},
+		{
+			name:         "protected branch glob",
+			mode:         PushForce,
+			protected:    []string{"release/*"},
+			initial:      map[string]string{"refs/heads/release/1.0": "b", "refs/heads/main": "b"},
+			local:        map[string]string{"refs/heads/release/1.0": "c", "refs/heads/main": "c"},
+			wantRejected: []string{"refs/heads/release/1.0"},
+			wantTarget:   map[string]string{"refs/heads/release/1.0": "b", "refs/heads/main": "c"},
+		},
+		{
+			name:       "lease matches",
+			mode:       PushForceWithLease,
+			initial:    map[string]string{"refs/heads/main": "b"},
+			local:      map[string]string{"refs/heads/main": "c"},
+			wantTarget: map[string]string{"refs/heads/main": "c"},
+		},
+		{
+			name:         "lease mismatch",
+			mode:         PushForceWithLease,
+			initial:      map[string]string{"refs/heads/main": "b", "refs/heads/tmp": "d"},
+			external:     map[string]string{"refs/heads/main": "d"},
+			local:        map[string]string{"refs/heads/main": "c"},
+			wantRejected: []string{"refs/heads/main"},
+			wantTarget:   map[string]string{"refs/heads/main": "d", "refs/heads/tmp": "d"},
+		},
+		{
+			name:         "no-force keeps tag",
+			mode:         PushNoForce,
+			initial:      map[string]string{"refs/tags/v1": "a"},
+			local:        map[string]string{"refs/tags/v1": "b", "refs/tags/v2": "b"},
+			wantRejected: []string{"refs/tags/v1"},
+			wantTarget:   map[string]string{"refs/tags/v1": "a", "refs/tags/v2": "b"},
+		},
+		{
+			name:       "force moves tag",
+			mode:       PushForce,
+			protected:  []string{"*"},
+			initial:    map[string]string{"refs/tags/v1": "a"},
+			local:      map[string]string{"refs/tags/v1": "b"},
+			wantTarget: map[string]string{"refs/tags/v1": "b"},
+		},
+		{
+			name:       "atomic with push options",
+			mode:       PushNoForce,
+			atomic:     true,
+			options:    map[string]string{"ci.skip": "true"},
+			initial:    map[string]string{"refs/heads/main": "a"},
+			local:      map[string]string{"refs/heads/main": "b", "refs/tags/v1": "b"},
+			wantTarget: map[string]string{"refs/heads/main": "b", "refs/tags/v1": "b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			localPath, local := newBareRepo(t, "local.git")
+			targetPath, _ := newBareRepo(t, "target.git")
+			commits := writeCommits(t, local)
+			m := NewGitMigrateDouble(&credential.Credential{CloneURL: localPath}, &credential.Credential{CloneURL: targetPath})
+
+			setRefs(t, local, commits, tt.initial, true)
+			if err := m.Push(localPath); err != nil {
+				t.Fatalf("initial Push() error = %v", err)
+			}
+			if len(tt.external) > 0 {
+				target, err := git.PlainOpen(targetPath)
+				if err != nil {
+					t.Fatal(err)
+				}
+				setRefs(t, target, commits, tt.external, false)
+			}
+
+			setRefs(t, local, commits, tt.local, true)
+			m.WithPushMode(tt.mode, tt.protected)
+			m.WithPushOptions(tt.atomic, tt.options)
+			err := m.Push(localPath)
+			var rejected []string
+			var rejectedErr *RejectedError
+			if errors.As(err, &rejectedErr) {
+				for _, ref := range rejectedErr.Refs {
+					rejected = append(rejected, ref.Ref)
+				}
+			} else if err != nil {
+				t.Fatalf("Push() error = %v", err)
+			}
+			slices.Sort(rejected)
+			if !reflect.DeepEqual(rejected, tt.wantRejected) {
+				t.Errorf("rejected = %v, want %v", rejected, tt.wantRejected)
+			}
+			if got := readRefs(t, targetPath, commits); !reflect.DeepEqual(got, tt.wantTarget) {
+				t.Errorf("target refs = %v, want %v", got, tt.wantTarget)
+			}
+		})
+	}
+}
+
+func TestBranchProtected(t *testing.T) {
+	m := NewGitMigrate()
+	m.WithPushMode(PushForce, []string{"main", "release/*"})
+	tests := []struct {
+		branch string
+		want   bool
+	}{
+		{"main", true},
+		{"release/1.0", true},
+		{"release/1.0/hotfix", false},
+		{"develop", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.branch, func(t *testing.T) {
+			if got := m.branchProtected(tt.branch); got != tt.want {
+				t.Errorf("branchProtected(%q) = %v, want %v", tt.branch, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package gitx
+
+import (
+	"errors"
+	"fmt"
+	"github.com/chihqiang/mpgrm/pkg/credential"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"slices"
+	"strings"
+)
+
+// 同步中单个分支或标签的处理结果
+const (
+	SyncUpToDate          = "up-to-date"          // 两端一致
+	SyncCreatedOnTarget   = "created-on-target"   // 只存在于源仓库，已推送到目标
+	SyncCreatedOnSource   = "created-on-source"   // 只存在于目标，双向同步时已推送到源仓库
+	SyncFastForwardTarget = "fast-forward-target" // 目标落后，已快进目标
+	SyncFastForwardSource = "fast-forward-source" // 源仓库落后，双向同步时已快进源仓库
+	SyncTargetOnly        = "target-only"         // 只存在于目标，单向同步时保持不变
+	SyncTargetAhead       = "target-ahead"        // 目标领先，单向同步时保持不变
+	SyncDiverged          = "diverged"            // 两端各有对方没有的提交，或同名标签指向不同对象，需要人工处理
+)
+
+// RefSync 为单个分支或标签的同步结果
+type RefSync struct {
+	Ref    string // 完整引用名，例如 refs/heads/main
+	Action string // 处理结果，见 Sync 常量
+	Source string // 源仓库中的哈希，不存在时为空
+	Target string // 目标仓库中的哈希，不存在时为空
+}
+
+// syncNamespace 返回 remote 的引用在本地裸仓库中的前缀
+func syncNamespace(remote string) string {
+	return "refs/sync/" + remote + "/"
+}
+
+// SyncRefs 将源仓库和目标仓库的分支、标签拉取到 path 下的裸仓库后只做快进更新，从不强制推送：
+// 目标落后时快进目标，bidirectional 为 true 时源仓库落后也快进源仓库。
+// 分叉的分支和指向不同对象的同名标签只在结果中报告。branches、tags 为空时同步所有分支、标签
+func (m *GitMigrate) SyncRefs(path string, branches, tags []string, bidirectional bool) ([]RefSync, error) {
+	repo, err := git.PlainOpen(path)
+	if errors.Is(err, git.ErrRepositoryNotExists) {
+		repo, err = git.PlainInit(path, true)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed open sync repo: %w", err)
+	}
+	remotes := map[string]*credential.Credential{"origin": m.form, "target": m.target}
+	refs := make(map[string]map[string]plumbing.Hash, len(remotes))
+	for name, remote := range remotes {
+		if refs[name], err = fetchNamespace(repo, name, remote); err != nil {
+			return nil, err
+		}
+	}
+	source, target := refs["origin"], refs["target"]
+
+	seen := make(map[string]bool, len(source)+len(target))
+	var names []string
+	for _, side := range []map[string]plumbing.Hash{source, target} {
+		for name := range side {
+			if !seen[name] && selectedRef(name, branches, tags) {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	slices.Sort(names)
+
+	var results []RefSync
+	var toTarget, toSource []config.RefSpec
+	var targetBranches, targetTags, sourceBranches, sourceTags int
+	for _, name := range names {
+		result := RefSync{Ref: name}
+		sourceHash, inSource := source[name]
+		targetHash, inTarget := target[name]
+		if inSource {
+			result.Source = sourceHash.String()
+		}
+		if inTarget {
+			result.Target = targetHash.String()
+		}
+		isBranch := plumbing.ReferenceName(name).IsBranch()
+		switch {
+		case inSource && inTarget && sourceHash == targetHash:
+			result.Action = SyncUpToDate
+		case !inTarget:
+			result.Action = SyncCreatedOnTarget
+		case !inSource && bidirectional:
+			result.Action = SyncCreatedOnSource
+		case !inSource:
+			result.Action = SyncTargetOnly
+		case !isBranch:
+			// 标签一经发布不应移动，指向不同对象时只报告
+			result.Action = SyncDiverged
+		default:
+			result.Action, err = compareBranch(repo, sourceHash, targetHash, bidirectional)
+			if err != nil {
+				return nil, fmt.Errorf("failed compare %s: %w", name, err)
+			}
+		}
+		// 不带 "+" 的 RefSpec 由 go-git 再次检查是否为快进，避免覆盖推送期间新增的提交
+		switch result.Action {
+		case SyncCreatedOnTarget, SyncFastForwardTarget:
+			toTarget = append(toTarget, config.RefSpec(fmt.Sprintf("%s%s:%s", syncNamespace("origin"), strings.TrimPrefix(name, "refs/"), name)))
+			if isBranch {
+				targetBranches++
+			} else {
+				targetTags++
+			}
+		case SyncCreatedOnSource, SyncFastForwardSource:
+			toSource = append(toSource, config.RefSpec(fmt.Sprintf("%s%s:%s", syncNamespace("target"), strings.TrimPrefix(name, "refs/"), name)))
+			if isBranch {
+				sourceBranches++
+			} else {
+				sourceTags++
+			}
+		}
+		results = append(results, result)
+	}
+	if err := pushRefSpecs(repo, "target", remotes["target"], toTarget); err != nil {
+		return results, err
+	}
+	recordPushed(m.target.CloneURL, "branch", targetBranches)
+	recordPushed(m.target.CloneURL, "tag", targetTags)
+	if err := pushRefSpecs(repo, "origin", remotes["origin"], toSource); err != nil {
+		return results, err
+	}
+	recordPushed(m.form.CloneURL, "branch", sourceBranches)
+	recordPushed(m.form.CloneURL, "tag", sourceTags)
+	return results, nil
+}
+
+// fetchNamespace 将远程的分支和标签拉取到 refs/sync/<name>/ 下，返回完整引用名到哈希的映射
+// 拉取前清空该前缀，使远程已删除的引用不会残留；远程为空仓库时返回空结果
+func fetchNamespace(repo *git.Repository, name string, remote *credential.Credential) (map[string]plumbing.Hash, error) {
+	if err := repo.DeleteRemote(name); err != nil && !errors.Is(err, git.ErrRemoteNotFound) {
+		return nil, fmt.Errorf("failed delete remote %s: %w", name, err)
+	}
+	if _, err := repo.CreateRemote(&config.RemoteConfig{Name: name, URLs: []string{remote.CloneURL}}); err != nil {
+		return nil, fmt.Errorf("failed create remote %s: %w", name, err)
+	}
+	namespace := syncNamespace(name)
+	iter, err := repo.References()
+	if err != nil {
+		return nil, err
+	}
+	var stale []plumbing.ReferenceName
+	_ = iter.ForEach(func(ref *plumbing.Reference) error {
+		if strings.HasPrefix(ref.Name().String(), namespace) {
+			stale = append(stale, ref.Name())
+		}
+		return nil
+	})
+	for _, ref := range stale {
+		if err := repo.Storer.RemoveReference(ref); err != nil {
+			return nil, err
+		}
+	}
+	err = repo.Fetch(&git.FetchOptions{
+		RemoteName: name,
+		RefSpecs: []config.RefSpec{
+			config.RefSpec("+refs/heads/*:" + namespace + "heads/*"),
+			config.RefSpec("+refs/tags/*:" + namespace + "tags/*"),
+		},
+		Auth: remote.GetGitAuth(),
+		Tags: git.NoTags,
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) && !errors.Is(err, transport.ErrEmptyRemoteRepository) {
+		return nil, fmt.Errorf("failed fetch %s: %w", remote.DisplayURL(), err)
+	}
+	result := make(map[string]plumbing.Hash)
+	iter, err = repo.References()
+	if err != nil {
+		return nil, err
+	}
+	err = iter.ForEach(func(ref *plumbing.Reference) error {
+		if rest, ok := strings.CutPrefix(ref.Name().String(), namespace); ok && ref.Type() == plumbing.HashReference {
+			result["refs/"+rest] = ref.Hash()
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, storer.ErrStop) {
+		return nil, err
+	}
+	return result, nil
+}
+
+// compareBranch 判断同名分支在两端的先后关系，返回应执行的操作
+func compareBranch(repo *git.Repository, sourceHash, targetHash plumbing.Hash, bidirectional bool) (string, error) {
+	sourceCommit, err := repo.CommitObject(sourceHash)
+	if err != nil {
+		return "", err
+	}
+	targetCommit, err := repo.CommitObject(targetHash)
+	if err != nil {
+		return "", err
+	}
+	if behind, err := targetCommit.IsAncestor(sourceCommit); err != nil {
+		return "", err
+	} else if behind {
+		return SyncFastForwardTarget, nil
+	}
+	ahead, err := sourceCommit.IsAncestor(targetCommit)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case ahead && bidirectional:
+		return SyncFastForwardSource, nil
+	case ahead:
+		return SyncTargetAhead, nil
+	default:
+		return SyncDiverged, nil
+	}
+}
+
+// selectedRef 判断引用是否在要同步的分支、标签范围内
+func selectedRef(name string, branches, tags []string) bool {
+	ref := plumbing.ReferenceName(name)
+	switch {
+	case ref.IsBranch():
+		return len(branches) == 0 || slices.Contains(branches, ref.Short())
+	case ref.IsTag():
+		return len(tags) == 0 || slices.Contains(tags, ref.Short())
+	default:
+		return false
+	}
+}
+
+// pushRefSpecs 以非强制方式推送 refSpecs 到远程 name
+func pushRefSpecs(repo *git.Repository, name string, remote *credential.Credential, refSpecs []config.RefSpec) error {
+	if len(refSpecs) == 0 {
+		return nil
+	}
+	err := repo.Push(&git.PushOptions{
+		RemoteName: name,
+		RefSpecs:   refSpecs,
+		Auth:       remote.GetGitAuth(),
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return fmt.Errorf("failed push to %s: %w", remote.DisplayURL(), err)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package gitx
+
+import (
+	"github.com/chihqiang/mpgrm/pkg/credential"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSyncRefs(t *testing.T) {
+	requireGit(t)
+	source := map[string]string{
+		"refs/heads/main": "b",
+		"refs/heads/dev":  "b",
+		"refs/heads/old":  "a",
+		"refs/tags/v1":    "a",
+		"refs/tags/v2":    "a",
+	}
+	target := map[string]string{
+		"refs/heads/main":    "c",
+		"refs/heads/dev":     "a",
+		"refs/heads/old":     "b",
+		"refs/heads/feature": "a",
+		"refs/tags/v2":       "b",
+	}
+	tests := []struct {
+		name          string
+		bidirectional bool
+		wantActions   map[string]string
+		wantSource    map[string]string
+		wantTarget    map[string]string
+	}{
+		{
+			name: "one-way",
+			wantActions: map[string]string{
+				"refs/heads/dev":     SyncFastForwardTarget,
+				"refs/heads/feature": SyncTargetOnly,
+				"refs/heads/main":    SyncDiverged,
+				"refs/heads/old":     SyncTargetAhead,
+				"refs/tags/v1":       SyncCreatedOnTarget,
+				"refs/tags/v2":       SyncDiverged,
+			},
+			wantSource: source,
+			wantTarget: map[string]string{
+				"refs/heads/main":    "c",
+				"refs/heads/dev":     "b",
+				"refs/heads/old":     "b",
+				"refs/heads/feature": "a",
+				"refs/tags/v1":       "a",
+				"refs/tags/v2":       "b",
+			},
+		},
+		{
+			name:          "bidirectional",
+			bidirectional: true,
+			wantActions: map[string]string{
+				"refs/heads/dev":     SyncFastForwardTarget,
+				"refs/heads/feature": SyncCreatedOnSource,
+				"refs/heads/main":    SyncDiverged,
+				"refs/heads/old":     SyncFastForwardSource,
+				"refs/tags/v1":       SyncCreatedOnTarget,
+				"refs/tags/v2":       SyncDiverged,
+			},
+			wantSource: map[string]string{
+				"refs/heads/main":    "b",
+				"refs/heads/dev":     "b",
+				"refs/heads/old":     "b",
+				"refs/heads/feature": "a",
+				"refs/tags/v1":       "a",
+				"refs/tags/v2":       "a",
+			},
+			wantTarget: map[string]string{
+				"refs/heads/main":    "c",
+				"refs/heads/dev":     "b",
+				"refs/heads/old":     "b",
+				"refs/heads/feature": "a",
+				"refs/tags/v1":       "a",
+				"refs/tags/v2":       "b",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sourcePath, sourceRepo := newBareRepo(t, "source.git")
+			targetPath, targetRepo := newBareRepo(t, "target.git")
+			commits := writeCommits(t, sourceRepo)
+			writeCommits(t, targetRepo)
+			setRefs(t, sourceRepo, commits, source, true)
+			setRefs(t, targetRepo, commits, target, true)
+
+			m := NewGitMigrateDouble(&credential.Credential{CloneURL: sourcePath}, &credential.Credential{CloneURL: targetPath})
+			results, err := m.SyncRefs(filepath.Join(t.TempDir(), "sync.git"), nil, nil, tt.bidirectional)
+			if err != nil {
+				t.Fatalf("SyncRefs() error = %v", err)
+			}
+			actions := make(map[string]string, len(results))
+			for _, result := range results {
+				actions[result.Ref] = result.Action
+			}
+			if !reflect.DeepEqual(actions, tt.wantActions) {
+				t.Errorf("actions = %v, want %v", actions, tt.wantActions)
+			}
+			if got := readRefs(t, sourcePath, commits); !reflect.DeepEqual(got, tt.wantSource) {
+				t.Errorf("source refs = %v, want %v", got, tt.wantSource)
+			}
+			if got := readRefs(t, targetPath, commits); !reflect.DeepEqual(got, tt.wantTarget) {
+				t.Errorf("target refs = %v, want %v", got, tt.wantTarget)
+			}
+		})
+	}
+}
+
+func TestSelectedRef(t *testing.T) {
+	tests := []struct {
+		name     string
+		branches []string
+		tags     []string
+		want     bool
+	}{
+		{"refs/heads/main", nil, nil, true},
+		{"refs/heads/main", []string{"main"}, nil, true},
+		{"refs/heads/dev", []string{"main"}, nil, false},
+		{"refs/tags/v1", []string{"main"}, nil, true},
+		{"refs/tags/v1", nil, []string{"v2"}, false},
+		{"refs/pull/1/head", nil, nil, false},
+	}
+	for _, tt := range tests {
+		if got := selectedRef(tt.name, tt.branches, tt.tags); got != tt.want {
+			t.Errorf("selectedRef(%q, %v, %v) = %v, want %v", tt.name, tt.branches, tt.tags, got, tt.want)
+		}
+	}
+}
@@ -1,9 +1,11 @@
 package httpx
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"fmt"
+	"github.com/chihqiang/mpgrm/pkg/metrics"
 	"io"
 	"net/http"
 	"runtime"
@@ -12,7 +14,7 @@ import (
 
 var (
 	defaultClient = &http.Client{
-		Transport: &http.Transport{
+		Transport: NewTransport(&http.Transport{
 			Proxy:                 http.ProxyFromEnvironment,
 			MaxIdleConns:          100,
 			IdleConnTimeout:       90 * time.Second,
@@ -23,7 +25,7 @@ var (
 				InsecureSkipVerify: true,
 			},
 			DisableKeepAlives: true,
-		},
+		}),
 		Timeout: 10 * time.Second,
 	}
 )
@@ -33,12 +35,42 @@ const (
 	retryDelay = 1 * time.Second
 )
 
+// StatusError 表示请求返回了非 2xx 状态码
+type StatusError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Body       []byte // 响应内容的前 512 字节
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s %s failed: status %d, body: %q", e.Method, e.URL, e.StatusCode, e.Body)
+}
+
+// retryable 判断状态码是否值得重试，除超时和限流外的客户端错误重试也不会成功
+func retryable(statusCode int) bool {
+	return statusCode >= 500 || statusCode == http.StatusRequestTimeout || statusCode == http.StatusTooManyRequests
+}
+
+// Request 发送请求，网络错误和可重试的状态码会退避重试
+// 请求体先完整读入内存，每次重试重新发送完整的内容
 func Request(ctx context.Context, method, url string, body io.Reader, headers map[string]string) (*http.Response, error) {
 	var lastErr error
 	baseDelay := retryDelay
 
+	var data []byte
+	if body != nil {
+		var err error
+		if data, err = io.ReadAll(body); err != nil {
+			return nil, fmt.Errorf("read request body: %w", err)
+		}
+	}
 	for attempt := 0; attempt <= maxRetries; attempt++ {
-		req, err := http.NewRequestWithContext(ctx, method, url, body)
+		var reader io.Reader
+		if body != nil {
+			reader = bytes.NewReader(data)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, url, reader)
 		if err != nil {
 			return nil, err
 		}
@@ -56,10 +88,14 @@ func Request(ctx context.Context, method, url string, body io.Reader, headers ma
 			defer func() {
 				_ = resp.Body.Close()
 			}()
-			lastErr = fmt.Errorf("%s %s failed: status %d, body: %q", method, url, resp.StatusCode, bodyBytes)
+			lastErr = &StatusError{Method: method, URL: url, StatusCode: resp.StatusCode, Body: bodyBytes}
+			if !retryable(resp.StatusCode) {
+				return nil, lastErr
+			}
 		}
 
 		if attempt < maxRetries {
+			metrics.HTTPRetries.WithLabelValues(req.URL.Host).Inc()
 			backoff := time.Duration(1<<attempt) * baseDelay
 			select {
 			case <-time.After(backoff):
@@ -44,7 +44,7 @@ func checkRemoteSupportRange(ctx context.Context, url string) (bool, int64, erro
 	if err != nil {
 		return false, 0, err
 	}
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := metricsClient.Do(req)
 	if err != nil {
 		return false, 0, err
 	}
@@ -61,7 +61,7 @@ func checkRemoteSupportRange(ctx context.Context, url string) (bool, int64, erro
 
 // fullDownload 整文件下载
 func fullDownload(ctx context.Context, url, filePath string) error {
-	resp, err := http.Get(url)
+	resp, err := metricsClient.Get(url)
 	if err != nil {
 		return fmt.Errorf("request failed: %w", err)
 	}
@@ -90,7 +90,7 @@ func resumeDownload(ctx context.Context, url, filePath string, start int64) erro
 	}
 	req.Header.Set("Range", fmt.Sprintf("bytes=%d-", start))
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := metricsClient.Do(req)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,59 @@
+package httpx
+
+import (
+	"github.com/chihqiang/mpgrm/pkg/metrics"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// metricsClient 为记录指标的共享客户端，不限制超时，用于文件下载、git 传输和平台 SDK
+var metricsClient = &http.Client{Transport: NewTransport(http.DefaultTransport)}
+
+// Client 返回记录请求次数、耗时和传输字节数的 HTTP 客户端
+func Client() *http.Client {
+	return metricsClient
+}
+
+// metricsTransport 在 base 之上记录请求指标
+type metricsTransport struct {
+	base http.RoundTripper
+}
+
+// NewTransport 返回记录请求次数、耗时和传输字节数的 RoundTripper
+func NewTransport(base http.RoundTripper) http.RoundTripper {
+	return &metricsTransport{base: base}
+}
+
+func (t *metricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	host := req.URL.Host
+	if req.Body != nil && req.Body != http.NoBody {
+		req = req.Clone(req.Context())
+		req.Body = &countingBody{ReadCloser: req.Body, host: host, direction: "upload"}
+	}
+	resp, err := t.base.RoundTrip(req)
+	code := "error"
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+		resp.Body = &countingBody{ReadCloser: resp.Body, host: host, direction: "download"}
+	}
+	metrics.HTTPRequests.WithLabelValues(host, req.Method, code).Inc()
+	metrics.HTTPDuration.WithLabelValues(host).Observe(time.Since(start).Seconds())
+	return resp, err
+}
+
+// countingBody 统计读取的字节数
+type countingBody struct {
+	io.ReadCloser
+	host, direction string
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 {
+		metrics.TransferBytes.WithLabelValues(b.host, b.direction).Add(float64(n))
+	}
+	return n, err
+}
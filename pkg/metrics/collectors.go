@@ -0,0 +1,39 @@
+package metrics
+
+// 同步过程中记录的指标
+var (
+	ReposTotal = NewCounter("mpgrm_repos_total",
+		"Repositories processed by sync commands, by result status.", "status")
+	RepoDuration = NewHistogram("mpgrm_repo_duration_seconds",
+		"Time spent syncing a single repository.", DefBuckets)
+	RefsPushed = NewCounter("mpgrm_refs_pushed_total",
+		"Branches and tags created or updated on target repositories, by target host and kind.", "host", "kind")
+	TransferBytes = NewCounter("mpgrm_transfer_bytes_total",
+		"Bytes transferred over HTTP, including git smart HTTP, by host and direction.", "host", "direction")
+	HTTPRequests = NewCounter("mpgrm_http_requests_total",
+		"HTTP requests sent to platforms, by host, method and status code.", "host", "method", "code")
+	HTTPRetries = NewCounter("mpgrm_http_retries_total",
+		"HTTP requests retried after a failure, by host.", "host")
+	HTTPDuration = NewHistogram("mpgrm_http_request_duration_seconds",
+		"HTTP request latency, by host.", []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}, "host")
+	RunDuration = NewHistogram("mpgrm_run_duration_seconds",
+		"Duration of mpgrm commands, by command and status.", DefBuckets, "command", "status")
+	LastRun = NewGauge("mpgrm_last_run_timestamp_seconds",
+		"Unix time a command last finished, by command and status.", "command", "status")
+)
+
+// serve 模式下记录的指标
+var (
+	JobRuns = NewCounter("mpgrm_job_runs_total",
+		"Finished job runs in serve mode, by job, trigger and status.", "job", "trigger", "status")
+	JobSkipped = NewCounter("mpgrm_job_skipped_total",
+		"Scheduled job runs skipped because the previous run was still going.", "job")
+	JobDuration = NewHistogram("mpgrm_job_duration_seconds",
+		"Duration of job runs in serve mode, by job.", DefBuckets, "job")
+	JobRunning = NewGauge("mpgrm_job_running",
+		"Whether a job is currently running (1) or idle (0).", "job")
+	JobLastSuccess = NewGauge("mpgrm_job_last_success_timestamp_seconds",
+		"Unix time of the last successful run of a job, for mirror freshness alerts.", "job")
+	Webhooks = NewCounter("mpgrm_webhooks_total",
+		"Webhooks received in serve mode, by platform and result.", "platform", "result")
+)
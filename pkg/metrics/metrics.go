@@ -0,0 +1,250 @@
+package metrics
+
+import (
+	"fmt"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Registry 为 mpgrm 的指标注册表，只包含 mpgrm 自身的指标
+var Registry = prometheus.NewRegistry()
+
+// DefBuckets 为耗时类直方图的默认分桶，单位秒
+var DefBuckets = []float64{0.1, 0.5, 1, 5, 10, 30, 60, 300, 900, 1800, 3600}
+
+// 按名称索引的指标，Merge 时使用
+var (
+	mu     sync.Mutex
+	byName = make(map[string]any)
+)
+
+// register 注册指标，同名指标重复注册时 panic
+func register(name string, c prometheus.Collector) {
+	Registry.MustRegister(c)
+	mu.Lock()
+	defer mu.Unlock()
+	byName[name] = c
+}
+
+// NewCounter 创建并注册计数器
+func NewCounter(name, help string, labelNames ...string) *prometheus.CounterVec {
+	c := prometheus.NewCounterVec(prometheus.CounterOpts{Name: name, Help: help}, labelNames)
+	register(name, c)
+	return c
+}
+
+// NewGauge 创建并注册仪表
+func NewGauge(name, help string, labelNames ...string) *prometheus.GaugeVec {
+	g := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: name, Help: help}, labelNames)
+	register(name, g)
+	return g
+}
+
+// NewHistogram 创建并注册直方图
+func NewHistogram(name, help string, buckets []float64, labelNames ...string) *Histogram {
+	h := &Histogram{
+		HistogramVec: prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: name, Help: help, Buckets: buckets}, labelNames),
+		desc:         prometheus.NewDesc(name, help, labelNames, nil),
+		labelNames:   labelNames,
+		merged:       make(map[string]*histogramData),
+	}
+	register(name, h)
+	return h
+}
+
+// Histogram 为直方图，输出时包含本进程的观测值和 Merge 合并的子进程观测值
+// 子进程只上报累计的分桶计数，无法逐个观测，因此单独保存后在输出时相加
+type Histogram struct {
+	*prometheus.HistogramVec
+	desc       *prometheus.Desc
+	labelNames []string
+
+	mu     sync.Mutex
+	merged map[string]*histogramData
+}
+
+// histogramData 为一组标签值对应的直方图数据
+type histogramData struct {
+	labels  []string
+	count   uint64
+	sum     float64
+	buckets map[float64]uint64 // 分桶上界对应的累计计数，不含 +Inf
+}
+
+// add 累加 m 中的直方图数据
+func (d *histogramData) add(m *dto.Histogram) {
+	d.count += m.GetSampleCount()
+	d.sum += m.GetSampleSum()
+	for _, b := range m.GetBucket() {
+		if !math.IsInf(b.GetUpperBound(), 1) {
+			d.buckets[b.GetUpperBound()] += b.GetCumulativeCount()
+		}
+	}
+}
+
+// Describe 实现 prometheus.Collector
+func (h *Histogram) Describe(ch chan<- *prometheus.Desc) {
+	ch <- h.desc
+}
+
+// Collect 实现 prometheus.Collector，输出本进程和合并的数据之和
+func (h *Histogram) Collect(ch chan<- prometheus.Metric) {
+	live := make(chan prometheus.Metric)
+	go func() {
+		h.HistogramVec.Collect(live)
+		close(live)
+	}()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	seen := make(map[string]bool)
+	for m := range live {
+		var pb dto.Metric
+		if err := m.Write(&pb); err != nil {
+			continue
+		}
+		labels, ok := labelValues(pb.GetLabel(), h.labelNames)
+		if !ok {
+			continue
+		}
+		key := strings.Join(labels, "\xff")
+		data := &histogramData{labels: labels, buckets: make(map[float64]uint64)}
+		data.add(pb.GetHistogram())
+		if merged, ok := h.merged[key]; ok {
+			data.count += merged.count
+			data.sum += merged.sum
+			for upper, n := range merged.buckets {
+				data.buckets[upper] += n
+			}
+		}
+		seen[key] = true
+		ch <- prometheus.MustNewConstHistogram(h.desc, data.count, data.sum, data.buckets, data.labels...)
+	}
+	for key, data := range h.merged {
+		if !seen[key] {
+			ch <- prometheus.MustNewConstHistogram(h.desc, data.count, data.sum, data.buckets, data.labels...)
+		}
+	}
+}
+
+// merge 合并子进程上报的一组直方图数据
+func (h *Histogram) merge(labels []string, m *dto.Histogram) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	key := strings.Join(labels, "\xff")
+	data, ok := h.merged[key]
+	if !ok {
+		data = &histogramData{labels: labels, buckets: make(map[float64]uint64)}
+		h.merged[key] = data
+	}
+	data.add(m)
+}
+
+// labelValues 按 names 的顺序返回标签值，标签与 names 不一致时 ok 为 false
+func labelValues(pairs []*dto.LabelPair, names []string) (values []string, ok bool) {
+	if len(pairs) != len(names) {
+		return nil, false
+	}
+	byLabel := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		byLabel[pair.GetName()] = pair.GetValue()
+	}
+	values = make([]string, 0, len(names))
+	for _, name := range names {
+		value, ok := byLabel[name]
+		if !ok {
+			return nil, false
+		}
+		values = append(values, value)
+	}
+	return values, true
+}
+
+// Snapshot 返回当前所有指标的 Prometheus 文本格式，用于写入执行报告
+func Snapshot() (string, error) {
+	var b strings.Builder
+	if err := WriteText(&b); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// Merge 将其它进程的 Snapshot 累加到当前进程，serve 模式下汇总各次执行的指标
+// 计数器和直方图累加，仪表取新值，未注册或类型不一致的指标忽略
+func Merge(text string) error {
+	if text == "" {
+		return nil
+	}
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(strings.NewReader(text))
+	if err != nil {
+		return fmt.Errorf("parse metrics: %w", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	for name, family := range families {
+		for _, m := range family.GetMetric() {
+			switch c := byName[name].(type) {
+			case *prometheus.CounterVec:
+				if family.GetType() != dto.MetricType_COUNTER {
+					continue
+				}
+				if counter, err := c.GetMetricWith(labelMap(m)); err == nil {
+					counter.Add(m.GetCounter().GetValue())
+				}
+			case *prometheus.GaugeVec:
+				if family.GetType() != dto.MetricType_GAUGE {
+					continue
+				}
+				if gauge, err := c.GetMetricWith(labelMap(m)); err == nil {
+					gauge.Set(m.GetGauge().GetValue())
+				}
+			case *Histogram:
+				if family.GetType() != dto.MetricType_HISTOGRAM {
+					continue
+				}
+				if labels, ok := labelValues(m.GetLabel(), c.labelNames); ok {
+					c.merge(labels, m.GetHistogram())
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// labelMap 返回指标的标签
+func labelMap(m *dto.Metric) prometheus.Labels {
+	labels := make(prometheus.Labels, len(m.GetLabel()))
+	for _, pair := range m.GetLabel() {
+		labels[pair.GetName()] = pair.GetValue()
+	}
+	return labels
+}
+
+// WriteText 以 Prometheus 文本格式输出所有指标
+func WriteText(w io.Writer) error {
+	families, err := Registry.Gather()
+	if err != nil {
+		return err
+	}
+	for _, family := range families {
+		if _, err := expfmt.MetricFamilyToText(w, family); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteFile 将所有指标写入 node_exporter textfile collector 读取的文件，先写临时文件再重命名，避免读到不完整的内容
+func WriteFile(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return prometheus.WriteToTextfile(path, Registry)
+}
@@ -0,0 +1,112 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	counter := NewCounter("mpgrm_test_merge_total", "Test counter.", "status")
+	gauge := NewGauge("mpgrm_test_merge_timestamp_seconds", "Test gauge.", "job")
+	histogram := NewHistogram("mpgrm_test_merge_duration_seconds", "Test histogram.", []float64{1, 10}, "job")
+	counter.WithLabelValues("success").Add(2)
+	gauge.WithLabelValues("mirror").Set(100)
+	histogram.WithLabelValues("mirror").Observe(0.5)
+
+	snapshot := `# TYPE mpgrm_test_merge_total counter
+mpgrm_test_merge_total{status="success"} 3
+mpgrm_test_merge_total{status="failed"} 1
+# TYPE mpgrm_test_merge_timestamp_seconds gauge
+mpgrm_test_merge_timestamp_seconds{job="mirror"} 200
+# TYPE mpgrm_test_merge_duration_seconds histogram
+mpgrm_test_merge_duration_seconds_bucket{job="mirror",le="1"} 0
+mpgrm_test_merge_duration_seconds_bucket{job="mirror",le="10"} 1
+mpgrm_test_merge_duration_seconds_bucket{job="mirror",le="+Inf"} 2
+mpgrm_test_merge_duration_seconds_sum{job="mirror"} 25
+mpgrm_test_merge_duration_seconds_count{job="mirror"} 2
+mpgrm_test_merge_duration_seconds_bucket{job="backup",le="1"} 1
+mpgrm_test_merge_duration_seconds_bucket{job="backup",le="10"} 1
+mpgrm_test_merge_duration_seconds_bucket{job="backup",le="+Inf"} 1
+mpgrm_test_merge_duration_seconds_sum{job="backup"} 0.25
+mpgrm_test_merge_duration_seconds_count{job="backup"} 1
+# TYPE mpgrm_test_unknown_total counter
+mpgrm_test_unknown_total 7
+`
+	if err := Merge(snapshot); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	if got := testutil.ToFloat64(counter.WithLabelValues("success")); got != 5 {
+		t.Errorf("success counter = %v, want 5", got)
+	}
+	if got := testutil.ToFloat64(counter.WithLabelValues("failed")); got != 1 {
+		t.Errorf("failed counter = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(gauge.WithLabelValues("mirror")); got != 200 {
+		t.Errorf("gauge = %v, want 200", got)
+	}
+
+	want := `# HELP mpgrm_test_merge_duration_seconds Test histogram.
+# TYPE mpgrm_test_merge_duration_seconds histogram
+mpgrm_test_merge_duration_seconds_bucket{job="backup",le="1"} 1
+mpgrm_test_merge_duration_seconds_bucket{job="backup",le="10"} 1
+mpgrm_test_merge_duration_seconds_bucket{job="backup",le="+Inf"} 1
+mpgrm_test_merge_duration_seconds_sum{job="backup"} 0.25
+mpgrm_test_merge_duration_seconds_count{job="backup"} 1
+mpgrm_test_merge_duration_seconds_bucket{job="mirror",le="1"} 1
+mpgrm_test_merge_duration_seconds_bucket{job="mirror",le="10"} 2
+mpgrm_test_merge_duration_seconds_bucket{job="mirror",le="+Inf"} 3
+mpgrm_test_merge_duration_seconds_sum{job="mirror"} 25.5
+mpgrm_test_merge_duration_seconds_count{job="mirror"} 3
+`
+	if err := testutil.CollectAndCompare(histogram, strings.NewReader(want)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMergeInvalid(t *testing.T) {
+	if err := Merge("not a metric {"); err == nil {
+		t.Error("Merge() error = nil, want parse error")
+	}
+	if err := Merge(""); err != nil {
+		t.Errorf("Merge(\"\") error = %v", err)
+	}
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	counter := NewCounter("mpgrm_test_snapshot_total", "Test counter.", "host")
+	counter.WithLabelValues("example.com").Inc()
+	snapshot, err := Snapshot()
+	if err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+	if !strings.Contains(snapshot, `mpgrm_test_snapshot_total{host="example.com"} 1`) {
+		t.Fatalf("Snapshot() missing counter:\n%s", snapshot)
+	}
+	if err := Merge(snapshot); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	if got := testutil.ToFloat64(counter.WithLabelValues("example.com")); got != 2 {
+		t.Errorf("counter = %v, want 2", got)
+	}
+}
+
+func TestWriteFile(t *testing.T) {
+	NewGauge("mpgrm_test_write_file", "Test gauge.").WithLabelValues().Set(1)
+	path := filepath.Join(t.TempDir(), "textfile", "mpgrm.prom")
+	if err := WriteFile(path); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "mpgrm_test_write_file 1\n") {
+		t.Errorf("WriteFile() content missing gauge:\n%s", data)
+	}
+	if err := testutil.GatherAndCompare(Registry, strings.NewReader("# HELP mpgrm_test_write_file Test gauge.\n# TYPE mpgrm_test_write_file gauge\nmpgrm_test_write_file 1\n"), "mpgrm_test_write_file"); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,72 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// SMTPConfig 为发送邮件的 SMTP 配置
+type SMTPConfig struct {
+	Addr     string   // 服务器地址，例如 smtp.example.com:587
+	Username string   // 为空时不进行认证
+	Password string   // 密码或授权码
+	From     string   // 发件人，为空时使用 Username
+	To       []string // 收件人
+}
+
+// Email 通过 SMTP 发送摘要邮件，服务器支持时使用 STARTTLS
+type Email struct {
+	SMTPConfig
+}
+
+func (e *Email) Name() string {
+	return KindEmail
+}
+
+func (e *Email) Notify(ctx context.Context, summary *Summary) error {
+	from := e.From
+	if from == "" {
+		from = e.Username
+	}
+	if from == "" {
+		return fmt.Errorf("email sender is required")
+	}
+	var auth smtp.Auth
+	if e.Username != "" {
+		host, _, err := net.SplitHostPort(e.Addr)
+		if err != nil {
+			return err
+		}
+		auth = smtp.PlainAuth("", e.Username, e.Password, host)
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(e.Addr, auth, from, e.To, e.message(from, summary))
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// message 生成纯文本邮件内容
+func (e *Email) message(from string, summary *Summary) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(e.To, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", summary.Title()))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(summary.Text(), "\n", "\r\n"))
+	b.WriteString("\r\n")
+	return []byte(b.String())
+}
@@ -0,0 +1,113 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+)
+
+// smtpMessage 为 SMTP 测试服务器收到的邮件
+type smtpMessage struct {
+	from string
+	to   []string
+	data string
+}
+
+// serveSMTP 在 127.0.0.1 上启动只支持最基本命令的 SMTP 服务器，接收一封邮件后返回
+func serveSMTP(t *testing.T) (addr string, received <-chan smtpMessage) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+	ch := make(chan smtpMessage, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+		tp := textproto.NewConn(conn)
+		var msg smtpMessage
+		_ = tp.PrintfLine("220 localhost ESMTP")
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				return
+			}
+			cmd := strings.ToUpper(line)
+			switch {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				_ = tp.PrintfLine("250 localhost")
+			case strings.HasPrefix(cmd, "MAIL FROM:"):
+				msg.from = strings.Trim(line[len("MAIL FROM:"):], "<>")
+				_ = tp.PrintfLine("250 OK")
+			case strings.HasPrefix(cmd, "RCPT TO:"):
+				msg.to = append(msg.to, strings.Trim(line[len("RCPT TO:"):], "<>"))
+				_ = tp.PrintfLine("250 OK")
+			case cmd == "DATA":
+				_ = tp.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+				data, err := tp.ReadDotBytes()
+				if err != nil {
+					return
+				}
+				msg.data = string(data)
+				_ = tp.PrintfLine("250 OK")
+			case cmd == "QUIT":
+				_ = tp.PrintfLine("221 Bye")
+				ch <- msg
+				return
+			default:
+				_ = tp.PrintfLine("502 Command not implemented")
+			}
+		}
+	}()
+	return ln.Addr().String(), ch
+}
+
+func TestEmailNotify(t *testing.T) {
+	addr, received := serveSMTP(t)
+	email := &Email{SMTPConfig: SMTPConfig{
+		Addr: addr,
+		From: "mpgrm@example.com",
+		To:   []string{"ops@example.com", "dev@example.com"},
+	}}
+	summary := testSummary()
+	if err := email.Notify(context.Background(), summary); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	msg := <-received
+	if msg.from != "mpgrm@example.com" {
+		t.Errorf("from = %q, want mpgrm@example.com", msg.from)
+	}
+	if strings.Join(msg.to, ",") != "ops@example.com,dev@example.com" {
+		t.Errorf("to = %q", msg.to)
+	}
+	header, err := textproto.NewReader(bufio.NewReader(strings.NewReader(msg.data))).ReadMIMEHeader()
+	if err != nil {
+		t.Fatalf("parse message header: %v", err)
+	}
+	if got := header.Get("To"); got != "ops@example.com, dev@example.com" {
+		t.Errorf("To = %q", got)
+	}
+	if got := header.Get("Subject"); got != summary.Title() {
+		t.Errorf("Subject = %q, want %q", got, summary.Title())
+	}
+	if got := header.Get("Content-Type"); got != "text/plain; charset=utf-8" {
+		t.Errorf("Content-Type = %q", got)
+	}
+	if !strings.Contains(msg.data, "- https://github.com/octo/lib.git -> https://gitea.example.com/mirror/lib.git: push rejected") {
+		t.Errorf("message body missing failure:\n%s", msg.data)
+	}
+}
+
+func TestEmailNotifyRequiresSender(t *testing.T) {
+	email := &Email{SMTPConfig: SMTPConfig{Addr: "127.0.0.1:25", To: []string{"ops@example.com"}}}
+	if err := email.Notify(context.Background(), testSummary()); err == nil {
+		t.Error("Notify() error = nil, want missing sender error")
+	}
+}
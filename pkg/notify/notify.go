@@ -0,0 +1,132 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"github.com/chihqiang/mpgrm/pkg/report"
+	"github.com/chihqiang/mpgrm/pkg/x"
+	"strings"
+	"time"
+)
+
+const (
+	OnAlways  = "always"  // 每次执行结束都发送通知
+	OnFailure = "failure" // 只在有失败时发送通知
+)
+
+// maxFailures 为消息正文中最多列出的失败仓库数量
+const maxFailures = 20
+
+// Notifier 为执行结束后的通知渠道
+type Notifier interface {
+	// Name 返回渠道名称，用于日志
+	Name() string
+	// Notify 发送执行摘要
+	Notify(ctx context.Context, summary *Summary) error
+}
+
+// Summary 为一次命令执行的摘要
+type Summary struct {
+	Command   string          `json:"command"`         // 执行的子命令，例如 "repo sync"
+	Status    string          `json:"status"`          // report.StatusSuccess 或 report.StatusFailed
+	Succeeded int             `json:"succeeded"`       // 成功的仓库数
+	Failed    int             `json:"failed"`          // 失败的仓库数
+	Duration  string          `json:"duration"`        // 总耗时
+	Error     string          `json:"error,omitempty"` // 命令整体的失败原因
+	Results   []report.Result `json:"results"`         // 各仓库的结果
+}
+
+// NewSummary 根据各仓库结果和命令返回的错误生成摘要
+func NewSummary(command string, results []report.Result, duration time.Duration, err error) *Summary {
+	s := &Summary{
+		Command:  command,
+		Status:   report.StatusSuccess,
+		Duration: duration.Round(time.Millisecond).String(),
+		Results:  results,
+	}
+	for _, result := range results {
+		if result.Status == report.StatusFailed {
+			s.Failed++
+		} else {
+			s.Succeeded++
+		}
+	}
+	if err != nil {
+		s.Error = x.RedactURLs(err.Error())
+	}
+	if err != nil || s.Failed > 0 {
+		s.Status = report.StatusFailed
+	}
+	return s
+}
+
+// Failures 返回失败的仓库结果
+func (s *Summary) Failures() []report.Result {
+	var failures []report.Result
+	for _, result := range s.Results {
+		if result.Status == report.StatusFailed {
+			failures = append(failures, result)
+		}
+	}
+	return failures
+}
+
+// Title 返回一行标题，例如 "mpgrm repo sync failed: 2 of 12 repositories failed"
+func (s *Summary) Title() string {
+	if s.Status == report.StatusSuccess {
+		return fmt.Sprintf("mpgrm %s succeeded: %d repositories in %s", s.Command, s.Succeeded, s.Duration)
+	}
+	if s.Failed > 0 {
+		return fmt.Sprintf("mpgrm %s failed: %d of %d repositories failed", s.Command, s.Failed, s.Failed+s.Succeeded)
+	}
+	return fmt.Sprintf("mpgrm %s failed", s.Command)
+}
+
+// Text 返回纯文本正文，包含成功数、失败数、耗时和失败原因
+func (s *Summary) Text() string {
+	var b strings.Builder
+	b.WriteString(s.Title())
+	fmt.Fprintf(&b, "\nSucceeded: %d, Failed: %d, Duration: %s", s.Succeeded, s.Failed, s.Duration)
+	if s.Error != "" {
+		fmt.Fprintf(&b, "\nError: %s", s.Error)
+	}
+	failures := s.Failures()
+	if len(failures) > 0 {
+		b.WriteString("\nFailures:")
+	}
+	for i, result := range failures {
+		if i == maxFailures {
+			fmt.Fprintf(&b, "\n... and %d more", len(failures)-maxFailures)
+			break
+		}
+		name := result.Repo
+		if result.Target != "" {
+			name += " -> " + result.Target
+		}
+		fmt.Fprintf(&b, "\n- %s: %s", name, result.Error)
+	}
+	return b.String()
+}
+
+// Parse 解析 kind=target 形式的通知渠道
+// kind 为 webhook、slack、dingtalk、feishu、wecom 时 target 为地址，为 email 时 target 为收件人
+func Parse(spec string, smtp SMTPConfig, secret string) (Notifier, error) {
+	kind, target, ok := strings.Cut(spec, "=")
+	if !ok || target == "" {
+		return nil, fmt.Errorf("invalid notify %q, expected kind=target", spec)
+	}
+	switch kind {
+	case KindWebhook:
+		return &Webhook{URL: target}, nil
+	case KindSlack, KindDingTalk, KindFeishu, KindWeCom:
+		return &Chat{Kind: kind, URL: target, Secret: secret}, nil
+	case KindEmail:
+		if smtp.Addr == "" {
+			return nil, fmt.Errorf("notify %q requires an SMTP server address", spec)
+		}
+		smtp.To = strings.Split(target, ";")
+		return &Email{SMTPConfig: smtp}, nil
+	default:
+		return nil, fmt.Errorf("unsupported notify kind %q", kind)
+	}
+}
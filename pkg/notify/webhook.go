@@ -0,0 +1,127 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/chihqiang/mpgrm/pkg/httpx"
+	"io"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	KindWebhook  = "webhook"  // 通用 JSON webhook，请求体为 Summary
+	KindSlack    = "slack"    // Slack 及兼容的 incoming webhook
+	KindDingTalk = "dingtalk" // 钉钉自定义机器人
+	KindFeishu   = "feishu"   // 飞书自定义机器人
+	KindWeCom    = "wecom"    // 企业微信群机器人
+	KindEmail    = "email"    // SMTP 邮件
+)
+
+// Webhook 将摘要以 JSON 格式 POST 到指定地址
+type Webhook struct {
+	URL string
+}
+
+func (w *Webhook) Name() string {
+	return KindWebhook
+}
+
+func (w *Webhook) Notify(ctx context.Context, summary *Summary) error {
+	return postJSON(ctx, w.URL, summary)
+}
+
+// Chat 将摘要以文本消息发送到聊天工具的机器人
+type Chat struct {
+	Kind   string // KindSlack、KindDingTalk、KindFeishu 或 KindWeCom
+	URL    string
+	Secret string // 钉钉、飞书机器人的签名密钥，未开启签名校验时为空
+}
+
+func (c *Chat) Name() string {
+	return c.Kind
+}
+
+func (c *Chat) Notify(ctx context.Context, summary *Summary) error {
+	text := summary.Text()
+	target := c.URL
+	var body map[string]any
+	switch c.Kind {
+	case KindSlack:
+		body = map[string]any{"text": text}
+	case KindDingTalk:
+		body = map[string]any{"msgtype": "text", "text": map[string]string{"content": text}}
+		if c.Secret != "" {
+			timestamp := strconv.FormatInt(time.Now().UnixMilli(), 10)
+			mac := hmac.New(sha256.New, []byte(c.Secret))
+			mac.Write([]byte(timestamp + "\n" + c.Secret))
+			sign := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+			u, err := url.Parse(c.URL)
+			if err != nil {
+				return err
+			}
+			query := u.Query()
+			query.Set("timestamp", timestamp)
+			query.Set("sign", sign)
+			u.RawQuery = query.Encode()
+			target = u.String()
+		}
+	case KindFeishu:
+		body = map[string]any{"msg_type": "text", "content": map[string]string{"text": text}}
+		if c.Secret != "" {
+			timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+			mac := hmac.New(sha256.New, []byte(timestamp+"\n"+c.Secret))
+			body["timestamp"] = timestamp
+			body["sign"] = base64.StdEncoding.EncodeToString(mac.Sum(nil))
+		}
+	case KindWeCom:
+		body = map[string]any{"msgtype": "text", "text": map[string]string{"content": text}}
+	default:
+		return fmt.Errorf("unsupported chat kind %q", c.Kind)
+	}
+	return postJSON(ctx, target, body)
+}
+
+// chatResponse 为钉钉、飞书、企业微信的响应，HTTP 200 时仍可能通过错误码返回失败
+type chatResponse struct {
+	ErrCode *int   `json:"errcode"` // 钉钉、企业微信
+	ErrMsg  string `json:"errmsg"`
+	Code    *int   `json:"code"` // 飞书
+	Msg     string `json:"msg"`
+}
+
+// postJSON 以 JSON 格式 POST body，并检查响应中的错误码
+func postJSON(ctx context.Context, target string, body any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	resp, err := httpx.Post(ctx, target, bytes.NewReader(data), map[string]string{"Content-Type": "application/json"})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return err
+	}
+	var result chatResponse
+	if json.Unmarshal(raw, &result) != nil {
+		return nil
+	}
+	if result.ErrCode != nil && *result.ErrCode != 0 {
+		return fmt.Errorf("errcode %d: %s", *result.ErrCode, result.ErrMsg)
+	}
+	if result.Code != nil && *result.Code != 0 {
+		return fmt.Errorf("code %d: %s", *result.Code, result.Msg)
+	}
+	return nil
+}
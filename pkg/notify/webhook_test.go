@@ -0,0 +1,208 @@
+package notify
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/chihqiang/mpgrm/pkg/report"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// request 为测试服务器收到的请求
+type request struct {
+	query url.Values
+	body  map[string]any
+}
+
+// newChatServer 返回记录请求的测试服务器，每次请求都以 response 响应
+func newChatServer(t *testing.T, response string) (*httptest.Server, func() []request) {
+	t.Helper()
+	var (
+		mu       sync.Mutex
+		requests []request
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q, want application/json", ct)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		mu.Lock()
+		requests = append(requests, request{query: r.URL.Query(), body: body})
+		mu.Unlock()
+		_, _ = io.WriteString(w, response)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() []request {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]request{}, requests...)
+	}
+}
+
+func testSummary() *Summary {
+	return NewSummary("repo sync", []report.Result{
+		{Repo: "https://github.com/octo/app.git", Status: report.StatusSuccess},
+		{Repo: "https://github.com/octo/lib.git", Target: "https://gitea.example.com/mirror/lib.git", Status: report.StatusFailed, Error: "push rejected"},
+	}, 0, nil)
+}
+
+func hmacBase64(key, message string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(message))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestWebhookNotify(t *testing.T) {
+	srv, requests := newChatServer(t, "ok")
+	summary := testSummary()
+	if err := (&Webhook{URL: srv.URL}).Notify(context.Background(), summary); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	got := requests()
+	if len(got) != 1 {
+		t.Fatalf("got %d requests, want 1", len(got))
+	}
+	want := map[string]any{
+		"command":   "repo sync",
+		"status":    report.StatusFailed,
+		"succeeded": float64(1),
+		"failed":    float64(1),
+		"duration":  "0s",
+	}
+	for k, v := range want {
+		if got[0].body[k] != v {
+			t.Errorf("body[%q] = %v, want %v", k, got[0].body[k], v)
+		}
+	}
+	if results, _ := got[0].body["results"].([]any); len(results) != 2 {
+		t.Errorf("body results = %v, want 2 results", got[0].body["results"])
+	}
+}
+
+func TestChatNotify(t *testing.T) {
+	text := testSummary().Text()
+	tests := []struct {
+		kind     string
+		response string
+		want     map[string]any
+	}{
+		{KindSlack, "ok", map[string]any{"text": text}},
+		{KindDingTalk, `{"errcode":0,"errmsg":"ok"}`, map[string]any{"msgtype": "text", "text": map[string]any{"content": text}}},
+		{KindFeishu, `{"code":0,"msg":"success"}`, map[string]any{"msg_type": "text", "content": map[string]any{"text": text}}},
+		{KindWeCom, `{"errcode":0,"errmsg":"ok"}`, map[string]any{"msgtype": "text", "text": map[string]any{"content": text}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.kind, func(t *testing.T) {
+			srv, requests := newChatServer(t, tt.response)
+			if err := (&Chat{Kind: tt.kind, URL: srv.URL}).Notify(context.Background(), testSummary()); err != nil {
+				t.Fatalf("Notify() error = %v", err)
+			}
+			got := requests()
+			if len(got) != 1 {
+				t.Fatalf("got %d requests, want 1", len(got))
+			}
+			if !reflect.DeepEqual(got[0].body, tt.want) {
+				t.Errorf("body = %v, want %v", got[0].body, tt.want)
+			}
+		})
+	}
+}
+
+func TestChatNotifyDingTalkSign(t *testing.T) {
+	srv, requests := newChatServer(t, `{"errcode":0,"errmsg":"ok"}`)
+	chat := &Chat{Kind: KindDingTalk, URL: srv.URL + "/robot/send?access_token=abc", Secret: "SEC123"}
+	if err := chat.Notify(context.Background(), testSummary()); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	query := requests()[0].query
+	if query.Get("access_token") != "abc" {
+		t.Errorf("access_token = %q, want abc", query.Get("access_token"))
+	}
+	timestamp := query.Get("timestamp")
+	if timestamp == "" {
+		t.Fatal("timestamp is missing")
+	}
+	if want := hmacBase64("SEC123", timestamp+"\nSEC123"); query.Get("sign") != want {
+		t.Errorf("sign = %q, want %q", query.Get("sign"), want)
+	}
+}
+
+func TestChatNotifyFeishuSign(t *testing.T) {
+	srv, requests := newChatServer(t, `{"code":0,"msg":"success"}`)
+	chat := &Chat{Kind: KindFeishu, URL: srv.URL, Secret: "SEC123"}
+	if err := chat.Notify(context.Background(), testSummary()); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	body := requests()[0].body
+	timestamp, _ := body["timestamp"].(string)
+	if timestamp == "" {
+		t.Fatal("timestamp is missing")
+	}
+	if want := hmacBase64(timestamp+"\nSEC123", ""); body["sign"] != want {
+		t.Errorf("sign = %v, want %q", body["sign"], want)
+	}
+}
+
+func TestChatNotifyErrorCode(t *testing.T) {
+	tests := []struct {
+		kind     string
+		response string
+		want     string
+	}{
+		{KindDingTalk, `{"errcode":310000,"errmsg":"sign not match"}`, "errcode 310000: sign not match"},
+		{KindFeishu, `{"code":19021,"msg":"sign match fail"}`, "code 19021: sign match fail"},
+		{KindWeCom, `{"errcode":93000,"errmsg":"invalid webhook url"}`, "errcode 93000: invalid webhook url"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.kind, func(t *testing.T) {
+			srv, _ := newChatServer(t, tt.response)
+			err := (&Chat{Kind: tt.kind, URL: srv.URL}).Notify(context.Background(), testSummary())
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("Notify() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebhookNotifyRetrySendsBody(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		bodies []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		defer mu.Unlock()
+		bodies = append(bodies, string(data))
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+	if err := (&Webhook{URL: srv.URL}).Notify(context.Background(), testSummary()); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(bodies) != 2 {
+		t.Fatalf("got %d requests, want 2", len(bodies))
+	}
+	if bodies[1] == "" || bodies[1] != bodies[0] {
+		t.Errorf("retried body = %q, want %q", bodies[1], bodies[0])
+	}
+	if !strings.Contains(bodies[1], `"command":"repo sync"`) {
+		t.Errorf("retried body = %q, want the summary", bodies[1])
+	}
+}
@@ -0,0 +1,66 @@
+package cnb
+
+import (
+	"context"
+	"fmt"
+	"github.com/chihqiang/mpgrm/pkg/httpx"
+	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"net/http"
+)
+
+// subGroup 为 CNB 子组织接口返回的组织信息
+type subGroup struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Path        string `json:"path"`
+	Description string `json:"description"`
+}
+
+func (p *Platform) ListSubOrgs(ctx context.Context, orgName string) ([]string, error) {
+	var subOrgs []string
+	err := httpx.Paginate[*subGroup](func(page int) ([]*subGroup, error) {
+		var groups []*subGroup
+		err := p.request(ctx, http.MethodGet, fmt.Sprintf("%s/-/sub-groups?page=%d&page_size=%d", orgName, page, 10), nil, &groups)
+		return groups, err
+	}, func(group *subGroup) {
+		subOrgs = append(subOrgs, group.Path)
+	})
+	return subOrgs, err
+}
+
+func (p *Platform) CreateSubOrg(ctx context.Context, parent, name string) error {
+	return p.request(ctx, http.MethodPost, "groups", map[string]string{
+		"path": fmt.Sprintf("%s/%s", parent, name),
+	}, nil)
+}
+
+// GetOrg 返回组织信息，name 可以是子组织的完整路径
+func (p *Platform) GetOrg(ctx context.Context, name string) (*platforms.OrgInfo, error) {
+	var group subGroup
+	if err := p.request(ctx, http.MethodGet, name, nil, &group); err != nil {
+		return nil, err
+	}
+	return &platforms.OrgInfo{
+		Name:        group.Path,
+		DisplayName: group.Name,
+		Description: group.Description,
+	}, nil
+}
+
+// CreateOrg CNB 组织不区分可见性，IsPrivate 会被忽略
+func (p *Platform) CreateOrg(ctx context.Context, org *platforms.OrgInfo) error {
+	return p.request(ctx, http.MethodPost, "groups", map[string]string{
+		"path":        org.Name,
+		"description": org.Description,
+	}, nil)
+}
+
+func (p *Platform) CurrentUser(ctx context.Context) (string, error) {
+	var user struct {
+		Username string `json:"username"`
+	}
+	if err := p.request(ctx, http.MethodGet, "user", nil, &user); err != nil {
+		return "", err
+	}
+	return user.Username, nil
+}
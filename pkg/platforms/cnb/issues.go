@@ -0,0 +1,146 @@
+package cnb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/chihqiang/mpgrm/pkg/httpx"
+	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// issueUser 为 CNB Issue 接口返回的用户信息
+type issueUser struct {
+	Username string `json:"username"`
+	Nickname string `json:"nickname"`
+}
+
+// issue 为 CNB Issue 接口返回的 Issue 信息
+type issue struct {
+	Number    string       `json:"number"`
+	Title     string       `json:"title"`
+	Body      string       `json:"body"`
+	State     string       `json:"state"`
+	Author    issueUser    `json:"author"`
+	Assignees []*issueUser `json:"assignees"`
+	CreatedAt time.Time    `json:"created_at"`
+	Labels    []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+}
+
+// issueComment 为 CNB Issue 评论接口返回的评论信息
+type issueComment struct {
+	ID        json.Number `json:"id"`
+	Body      string      `json:"body"`
+	Author    issueUser   `json:"author"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+func (p *Platform) ListIssues(ctx context.Context, fullName string) ([]*platforms.IssueInfo, error) {
+	var issues []*platforms.IssueInfo
+	// CNB 的 Issue 列表需按状态分别查询
+	for _, state := range []string{platforms.IssueStateOpen, platforms.IssueStateClosed} {
+		err := httpx.Paginate[*issue](func(page int) ([]*issue, error) {
+			var items []*issue
+			err := p.request(ctx, http.MethodGet, fmt.Sprintf("%s/-/issues?state=%s&page=%d&page_size=%d", fullName, state, page, 20), nil, &items)
+			return items, err
+		}, func(item *issue) {
+			issues = append(issues, p.toIssueInfo(fullName, item))
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	// 列表接口不返回内容，需逐个获取详情
+	for _, info := range issues {
+		var detail issue
+		if err := p.request(ctx, http.MethodGet, fmt.Sprintf("%s/-/issues/%s", fullName, info.Number), nil, &detail); err != nil {
+			return nil, err
+		}
+		info.Body = detail.Body
+	}
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].CreatedAt.Before(issues[j].CreatedAt)
+	})
+	return issues, nil
+}
+
+func (p *Platform) CreateIssue(ctx context.Context, fullName string, info *platforms.IssueInfo) (*platforms.IssueInfo, error) {
+	body := map[string]any{
+		"title": info.Title,
+		"body":  info.Body,
+	}
+	if len(info.Labels) > 0 {
+		body["labels"] = info.Labels
+	}
+	if len(info.Assignees) > 0 {
+		body["assignees"] = info.Assignees
+	}
+	var created issue
+	if err := p.request(ctx, http.MethodPost, fmt.Sprintf("%s/-/issues", fullName), body, &created); err != nil {
+		return nil, err
+	}
+	result := p.toIssueInfo(fullName, &created)
+	if info.State == platforms.IssueStateClosed {
+		if err := p.UpdateIssueState(ctx, fullName, result.Number, platforms.IssueStateClosed); err != nil {
+			return result, err
+		}
+		result.State = platforms.IssueStateClosed
+	}
+	return result, nil
+}
+
+func (p *Platform) UpdateIssueState(ctx context.Context, fullName, number, state string) error {
+	return p.request(ctx, http.MethodPatch, fmt.Sprintf("%s/-/issues/%s", fullName, number), map[string]string{
+		"state": state,
+	}, nil)
+}
+
+func (p *Platform) ListIssueComments(ctx context.Context, fullName, number string) ([]*platforms.CommentInfo, error) {
+	var comments []*platforms.CommentInfo
+	err := httpx.Paginate[*issueComment](func(page int) ([]*issueComment, error) {
+		var items []*issueComment
+		err := p.request(ctx, http.MethodGet, fmt.Sprintf("%s/-/issues/%s/comments?page=%d&page_size=%d", fullName, number, page, 20), nil, &items)
+		return items, err
+	}, func(comment *issueComment) {
+		id, _ := comment.ID.Int64()
+		comments = append(comments, &platforms.CommentInfo{
+			ID:        id,
+			Body:      comment.Body,
+			Author:    comment.Author.Username,
+			CreatedAt: comment.CreatedAt,
+		})
+	})
+	return comments, err
+}
+
+func (p *Platform) CreateIssueComment(ctx context.Context, fullName, number, body string) error {
+	return p.request(ctx, http.MethodPost, fmt.Sprintf("%s/-/issues/%s/comments", fullName, number), map[string]string{
+		"body": body,
+	}, nil)
+}
+
+func (p *Platform) toIssueInfo(fullName string, item *issue) *platforms.IssueInfo {
+	info := &platforms.IssueInfo{
+		Number:    item.Number,
+		Title:     item.Title,
+		Body:      item.Body,
+		State:     platforms.IssueStateOpen,
+		Author:    item.Author.Username,
+		CreatedAt: item.CreatedAt,
+		URL:       fmt.Sprintf("%s/%s/-/issues/%s", downloadURL, fullName, item.Number),
+	}
+	if item.State == platforms.IssueStateClosed {
+		info.State = platforms.IssueStateClosed
+	}
+	for _, label := range item.Labels {
+		info.Labels = append(info.Labels, label.Name)
+	}
+	for _, assignee := range item.Assignees {
+		info.Assignees = append(info.Assignees, assignee.Username)
+	}
+	return info
+}
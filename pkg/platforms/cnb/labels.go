@@ -0,0 +1,53 @@
+package cnb
+
+import (
+	"context"
+	"fmt"
+	"github.com/chihqiang/mpgrm/pkg/httpx"
+	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"net/http"
+	"net/url"
+)
+
+// label 为 CNB 标签接口返回的标签信息
+type label struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Color       string `json:"color"`
+	Description string `json:"description"`
+}
+
+func (p *Platform) ListLabels(ctx context.Context, fullName string) ([]*platforms.LabelInfo, error) {
+	var labels []*platforms.LabelInfo
+	err := httpx.Paginate[*label](func(page int) ([]*label, error) {
+		var items []*label
+		err := p.request(ctx, http.MethodGet, fmt.Sprintf("%s/-/labels?page=%d&page_size=%d", fullName, page, 50), nil, &items)
+		return items, err
+	}, func(item *label) {
+		labels = append(labels, &platforms.LabelInfo{
+			Name:        item.Name,
+			Color:       platforms.NormalizeColor(item.Color),
+			Description: item.Description,
+		})
+	})
+	return labels, err
+}
+
+func (p *Platform) CreateLabel(ctx context.Context, fullName string, info *platforms.LabelInfo) error {
+	return p.request(ctx, http.MethodPost, fmt.Sprintf("%s/-/labels", fullName), map[string]string{
+		"name":        info.Name,
+		"color":       "#" + platforms.NormalizeColor(info.Color),
+		"description": info.Description,
+	}, nil)
+}
+
+func (p *Platform) UpdateLabel(ctx context.Context, fullName string, info *platforms.LabelInfo) error {
+	return p.request(ctx, http.MethodPatch, fmt.Sprintf("%s/-/labels/%s", fullName, url.PathEscape(info.Name)), map[string]string{
+		"color":       "#" + platforms.NormalizeColor(info.Color),
+		"description": info.Description,
+	}, nil)
+}
+
+func (p *Platform) LabelDescription() bool {
+	return true
+}
@@ -0,0 +1,81 @@
+package cnb
+
+import (
+	"context"
+	"fmt"
+	"github.com/chihqiang/mpgrm/pkg/httpx"
+	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"net/http"
+	"net/url"
+)
+
+// member 为 CNB 仓库成员接口返回的成员信息
+type member struct {
+	Username    string `json:"username"`
+	AccessLevel string `json:"access_level"`
+}
+
+// CNB 使用 Guest/Reporter/Developer/Master/Owner 五级角色
+var accessLevels = map[string]string{
+	platforms.PermissionRead:  "Reporter",
+	platforms.PermissionWrite: "Developer",
+	platforms.PermissionAdmin: "Master",
+}
+
+// fromAccessLevel 将 CNB 的角色转换为统一权限
+func fromAccessLevel(level string) string {
+	switch level {
+	case "Master", "Owner":
+		return platforms.PermissionAdmin
+	case "Developer":
+		return platforms.PermissionWrite
+	default:
+		return platforms.PermissionRead
+	}
+}
+
+func (p *Platform) listMembers(ctx context.Context, fullName string) ([]*member, error) {
+	var members []*member
+	err := httpx.Paginate[*member](func(page int) ([]*member, error) {
+		var items []*member
+		err := p.request(ctx, http.MethodGet, fmt.Sprintf("%s/-/members?page=%d&page_size=%d", fullName, page, 50), nil, &items)
+		return items, err
+	}, func(item *member) {
+		members = append(members, item)
+	})
+	return members, err
+}
+
+func (p *Platform) ListCollaborators(ctx context.Context, fullName string) ([]*platforms.CollaboratorInfo, error) {
+	members, err := p.listMembers(ctx, fullName)
+	if err != nil {
+		return nil, err
+	}
+	collaborators := make([]*platforms.CollaboratorInfo, 0, len(members))
+	for _, item := range members {
+		collaborators = append(collaborators, &platforms.CollaboratorInfo{
+			Username:   item.Username,
+			Permission: fromAccessLevel(item.AccessLevel),
+		})
+	}
+	return collaborators, nil
+}
+
+// AddCollaborator 已是成员时修改角色，否则以外部协作者身份添加
+func (p *Platform) AddCollaborator(ctx context.Context, fullName string, collaborator *platforms.CollaboratorInfo) error {
+	members, err := p.listMembers(ctx, fullName)
+	if err != nil {
+		return err
+	}
+	route := fmt.Sprintf("%s/-/members/%s", fullName, url.PathEscape(collaborator.Username))
+	body := map[string]any{
+		"access_level": accessLevels[collaborator.Permission],
+	}
+	for _, item := range members {
+		if item.Username == collaborator.Username {
+			return p.request(ctx, http.MethodPut, route, body, nil)
+		}
+	}
+	body["is_outside_collaborator"] = true
+	return p.request(ctx, http.MethodPost, route, body, nil)
+}
@@ -1,9 +1,15 @@
 package cnb
 
 import (
+	"bytes"
 	"cnb.cool/cnb/sdk/go-cnb/cnb"
+	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/chihqiang/mpgrm/pkg/credential"
+	"github.com/chihqiang/mpgrm/pkg/httpx"
+	"io"
+	"strings"
 )
 
 const (
@@ -40,5 +46,35 @@ func (p *Platform) GetClient() (*cnb.Client, error) {
 	if p.ApiURL == "" {
 		p.ApiURL = ApiURL
 	}
-	return cnb.NewClient(nil).WithAuthToken(p.Credential.Token).WithURLs(p.ApiURL)
+	return cnb.NewClient(httpx.Client()).WithAuthToken(p.Credential.Token).WithURLs(p.ApiURL)
+}
+
+// request 直接调用 CNB OpenAPI，用于 SDK 尚未覆盖的接口
+// body 不为 nil 时以 JSON 发送，d 不为 nil 时将响应 JSON 解码到 d
+func (p *Platform) request(ctx context.Context, method, route string, body, d any) error {
+	if p.ApiURL == "" {
+		p.ApiURL = ApiURL
+	}
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	apiURL := strings.TrimSuffix(p.ApiURL, "/") + "/" + strings.TrimPrefix(route, "/")
+	resp, err := httpx.Request(ctx, method, apiURL, reader, map[string]string{
+		"Accept":        "application/vnd.cnb.api+json",
+		"Authorization": "Bearer " + p.Credential.Token,
+		"Content-Type":  "application/json",
+	})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if d == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(d)
 }
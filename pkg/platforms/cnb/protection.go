@@ -0,0 +1,86 @@
+package cnb
+
+import (
+	"context"
+	"fmt"
+	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"net/http"
+)
+
+// branchProtection 为 CNB 分支保护接口的规则信息
+type branchProtection struct {
+	ID                             string `json:"id,omitempty"`
+	Rule                           string `json:"rule"`
+	AllowForcePushes               bool   `json:"allow_force_pushes"`
+	AllowDeletions                 bool   `json:"allow_deletions"`
+	AllowPushes                    bool   `json:"allow_pushes"`
+	AllowCreation                  bool   `json:"allow_creation"`
+	AllowMasterPushes              bool   `json:"allow_master_pushes"`
+	AllowMasterForcePushes         bool   `json:"allow_master_force_pushes"`
+	AllowMasterDeletions           bool   `json:"allow_master_deletions"`
+	AllowMasterCreation            bool   `json:"allow_master_creation"`
+	RequiredPullRequestReviews     bool   `json:"required_pull_request_reviews"`
+	RequiredApprovedReviewCount    int    `json:"required_approved_review_count"`
+	RequiredMustPushViaPullRequest bool   `json:"required_must_push_via_pull_request"`
+	RequiredStatusChecks           bool   `json:"required_status_checks"`
+}
+
+func (p *Platform) ListProtections(ctx context.Context, fullName string) ([]*platforms.ProtectionRule, error) {
+	var items []*branchProtection
+	if err := p.request(ctx, http.MethodGet, fmt.Sprintf("%s/-/settings/branch-protections", fullName), nil, &items); err != nil {
+		return nil, err
+	}
+	rules := make([]*platforms.ProtectionRule, 0, len(items))
+	for _, item := range items {
+		rules = append(rules, &platforms.ProtectionRule{
+			Branch:          item.Rule,
+			RequiredReviews: item.RequiredApprovedReviewCount,
+			RestrictPush:    !item.AllowPushes,
+			BlockForcePush:  !item.AllowForcePushes,
+			BlockDeletion:   !item.AllowDeletions,
+			EnforceAdmins:   !item.AllowMasterPushes,
+		})
+	}
+	return rules, nil
+}
+
+// SetProtection CNB 的状态检查只能整体开启，规则中的推送白名单无法生效，以 UnenforcedError 返回
+func (p *Platform) SetProtection(ctx context.Context, fullName string, rule *platforms.ProtectionRule) error {
+	var items []*branchProtection
+	if err := p.request(ctx, http.MethodGet, fmt.Sprintf("%s/-/settings/branch-protections", fullName), nil, &items); err != nil {
+		return err
+	}
+	body := &branchProtection{
+		Rule:                           rule.Branch,
+		AllowForcePushes:               !rule.BlockForcePush,
+		AllowDeletions:                 !rule.BlockDeletion,
+		AllowPushes:                    !rule.RestrictPush && rule.RequiredReviews == 0,
+		RequiredPullRequestReviews:     rule.RequiredReviews > 0,
+		RequiredApprovedReviewCount:    rule.RequiredReviews,
+		RequiredMustPushViaPullRequest: rule.RequiredReviews > 0,
+		RequiredStatusChecks:           len(rule.StatusChecks) > 0,
+		AllowCreation:                  true,
+		AllowMasterPushes:              !rule.EnforceAdmins,
+		AllowMasterForcePushes:         !rule.EnforceAdmins && !rule.BlockForcePush,
+		AllowMasterDeletions:           !rule.EnforceAdmins && !rule.BlockDeletion,
+		AllowMasterCreation:            true,
+	}
+	method, route := http.MethodPost, fmt.Sprintf("%s/-/settings/branch-protections", fullName)
+	for _, item := range items {
+		if item.Rule == rule.Branch {
+			method, route = http.MethodPatch, fmt.Sprintf("%s/-/settings/branch-protections/%s", fullName, item.ID)
+			break
+		}
+	}
+	if err := p.request(ctx, method, route, body, nil); err != nil {
+		return err
+	}
+	var unenforced []string
+	if len(rule.PushUsers) > 0 {
+		unenforced = append(unenforced, "push_users")
+	}
+	if len(rule.PushTeams) > 0 {
+		unenforced = append(unenforced, "push_teams")
+	}
+	return platforms.Unenforced(unenforced...)
+}
@@ -0,0 +1,147 @@
+package cnb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/chihqiang/mpgrm/pkg/httpx"
+	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// pullBranch 为 CNB 合并请求接口返回的分支信息
+type pullBranch struct {
+	Ref string `json:"ref"`
+	Sha string `json:"sha"`
+}
+
+// pull 为 CNB 合并请求接口返回的合并请求信息
+type pull struct {
+	Number    string     `json:"number"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	State     string     `json:"state"`
+	Author    issueUser  `json:"author"`
+	Head      pullBranch `json:"head"`
+	Base      pullBranch `json:"base"`
+	CreatedAt time.Time  `json:"created_at"`
+	MergedAt  *time.Time `json:"merged_at"`
+}
+
+// pullFile 为 CNB 合并请求变更文件接口返回的文件信息
+type pullFile struct {
+	Filename  string `json:"filename"`
+	Status    string `json:"status"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+}
+
+// pullComment 为 CNB 合并请求评论接口返回的评论信息
+type pullComment struct {
+	ID        json.Number `json:"id"`
+	Body      string      `json:"body"`
+	Author    issueUser   `json:"author"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+func (p *Platform) ListPulls(ctx context.Context, fullName string) ([]*platforms.PullInfo, error) {
+	var pulls []*platforms.PullInfo
+	err := httpx.Paginate[*pull](func(page int) ([]*pull, error) {
+		var items []*pull
+		err := p.request(ctx, http.MethodGet, fmt.Sprintf("%s/-/pulls?state=all&page=%d&page_size=%d", fullName, page, 20), nil, &items)
+		return items, err
+	}, func(item *pull) {
+		pulls = append(pulls, p.toPullInfo(fullName, item))
+	})
+	sort.SliceStable(pulls, func(i, j int) bool {
+		return pulls[i].CreatedAt.Before(pulls[j].CreatedAt)
+	})
+	return pulls, err
+}
+
+func (p *Platform) ListPullFiles(ctx context.Context, fullName, number string) ([]*platforms.PullFileInfo, error) {
+	var items []*pullFile
+	if err := p.request(ctx, http.MethodGet, fmt.Sprintf("%s/-/pulls/%s/files", fullName, number), nil, &items); err != nil {
+		return nil, err
+	}
+	files := make([]*platforms.PullFileInfo, 0, len(items))
+	for _, item := range items {
+		files = append(files, &platforms.PullFileInfo{
+			Filename:  item.Filename,
+			Status:    item.Status,
+			Additions: item.Additions,
+			Deletions: item.Deletions,
+		})
+	}
+	return files, nil
+}
+
+func (p *Platform) ListPullComments(ctx context.Context, fullName, number string) ([]*platforms.CommentInfo, error) {
+	var comments []*platforms.CommentInfo
+	err := httpx.Paginate[*pullComment](func(page int) ([]*pullComment, error) {
+		var items []*pullComment
+		err := p.request(ctx, http.MethodGet, fmt.Sprintf("%s/-/pulls/%s/comments?page=%d&page_size=%d", fullName, number, page, 20), nil, &items)
+		return items, err
+	}, func(comment *pullComment) {
+		id, _ := comment.ID.Int64()
+		comments = append(comments, &platforms.CommentInfo{
+			ID:        id,
+			Body:      comment.Body,
+			Author:    comment.Author.Username,
+			CreatedAt: comment.CreatedAt,
+		})
+	})
+	return comments, err
+}
+
+func (p *Platform) CreatePull(ctx context.Context, fullName string, info *platforms.PullInfo) (*platforms.PullInfo, error) {
+	var created pull
+	if err := p.request(ctx, http.MethodPost, fmt.Sprintf("%s/-/pulls", fullName), map[string]string{
+		"title": info.Title,
+		"head":  info.Head,
+		"base":  info.Base,
+		"body":  info.Body,
+	}, &created); err != nil {
+		return nil, err
+	}
+	result := p.toPullInfo(fullName, &created)
+	if info.State != platforms.PullStateOpen {
+		if err := p.request(ctx, http.MethodPatch, fmt.Sprintf("%s/-/pulls/%s", fullName, result.Number), map[string]string{
+			"state": platforms.PullStateClosed,
+		}, nil); err != nil {
+			return result, err
+		}
+		result.State = platforms.PullStateClosed
+	}
+	return result, nil
+}
+
+func (p *Platform) CreatePullComment(ctx context.Context, fullName, number, body string) error {
+	return p.request(ctx, http.MethodPost, fmt.Sprintf("%s/-/pulls/%s/comments", fullName, number), map[string]string{
+		"body": body,
+	}, nil)
+}
+
+func (p *Platform) toPullInfo(fullName string, item *pull) *platforms.PullInfo {
+	info := &platforms.PullInfo{
+		Number:    item.Number,
+		Title:     item.Title,
+		Body:      item.Body,
+		State:     item.State,
+		Head:      item.Head.Ref,
+		HeadSHA:   item.Head.Sha,
+		Base:      item.Base.Ref,
+		Author:    item.Author.Username,
+		CreatedAt: item.CreatedAt,
+		URL:       fmt.Sprintf("%s/%s/-/pulls/%s", downloadURL, fullName, item.Number),
+	}
+	if item.MergedAt != nil || item.State == platforms.PullStateMerged {
+		info.State = platforms.PullStateMerged
+		if item.MergedAt != nil {
+			info.MergedAt = *item.MergedAt
+		}
+	}
+	return info
+}
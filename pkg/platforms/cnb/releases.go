@@ -42,8 +42,11 @@ func (p *Platform) GetTagReleaseInfo(ctx context.Context, fullName, tagName stri
 	if err != nil {
 		return nil, err
 	}
-	byTag, _, err := client.Releases.GetReleaseByTag(ctx, fullName, tagName)
+	byTag, resp, err := client.Releases.GetReleaseByTag(ctx, fullName, tagName)
 	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("%w: %w", platforms.ErrNotFound, err)
+		}
 		return nil, fmt.Errorf("get release by tag failed: %w", err)
 	}
 	riID, _ := strconv.ParseInt(byTag.Id, 10, 64)
@@ -60,6 +63,7 @@ func (p *Platform) GetTagReleaseInfo(ctx context.Context, fullName, tagName stri
 			ID:   aID,
 			Name: asset.Name,
 			URL:  fmt.Sprintf("%s%s", downloadURL, asset.Path),
+			Size: platforms.UnknownSize, // CNB 接口不返回附件大小
 		})
 	}
 	return rfo, nil
@@ -7,11 +7,15 @@ import (
 	"fmt"
 	"github.com/chihqiang/mpgrm/pkg/httpx"
 	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"github.com/chihqiang/mpgrm/pkg/x"
+	"net/http"
 	"strconv"
+	"strings"
 )
 
 const VisibilityLevelSecret = "Secret"
 const VisibilityLevelPrivate = "Private"
+const VisibilityLevelPublic = "Public"
 
 func (p *Platform) ListOrgRepo(ctx context.Context, orgName string) ([]*platforms.RepoInfo, error) {
 	client, err := p.GetClient()
@@ -30,13 +34,19 @@ func (p *Platform) ListOrgRepo(ctx context.Context, orgName string) ([]*platform
 			return
 		}
 		idInt64, _ := strconv.ParseInt(repo.Id, 10, 64)
+		// CNB 接口不返回仓库大小，Size 保持为 0
 		rInfo := &platforms.RepoInfo{
 			ID:          idInt64,
 			Name:        repo.Name,
 			FullName:    repo.Path,
 			Description: repo.Description,
 			Homepage:    repo.Site,
-			IsPrivate:   repo.VisibilityLevel == VisibilityLevelPrivate,
+			IsPrivate:   repo.VisibilityLevel != VisibilityLevelPublic,
+			Topics:      x.StringSplit(repo.Topics, ","),
+			License:     repo.License,
+			Language:    repo.Language,
+			Stars:       int(repo.StarCount),
+			Forks:       int(repo.ForkCount),
 		}
 		rInfo.CloneURL = fmt.Sprintf("%s/%s.git", cloneURL, rInfo.FullName)
 		rInfos = append(rInfos, rInfo)
@@ -53,8 +63,11 @@ func (p *Platform) GetRepoDetail(ctx context.Context, fullName string) (*platfor
 	if err != nil {
 		return nil, err
 	}
-	repo, _, err := client.Repositories.GetByID(ctx, fullName)
+	repo, resp, err := client.Repositories.GetByID(ctx, fullName)
 	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("%w: %w", platforms.ErrNotFound, err)
+		}
 		return nil, err
 	}
 	//https://cnb.cool/cnb/sdk/go-cnb/-/issues/55
@@ -65,7 +78,12 @@ func (p *Platform) GetRepoDetail(ctx context.Context, fullName string) (*platfor
 		FullName:    repo.Path,
 		Description: repo.Description,
 		Homepage:    repo.Site,
-		IsPrivate:   repo.VisibilityLevel == VisibilityLevelPrivate,
+		IsPrivate:   repo.VisibilityLevel != VisibilityLevelPublic,
+		Topics:      x.StringSplit(repo.Topics, ","),
+		License:     repo.License,
+		Language:    repo.Language,
+		Stars:       int(repo.StarCount),
+		Forks:       int(repo.ForkCount),
 	}, nil
 }
 
@@ -90,7 +108,61 @@ func (p *Platform) CreateRepo(ctx context.Context, repo *platforms.RepoInfo) err
 		Description: repo.Description,
 		Visibility:  visibility,
 	})
-	return err
+	if err != nil || len(repo.Topics) == 0 {
+		return err
+	}
+	// 创建接口不支持主题，需在创建后单独设置
+	return p.request(ctx, http.MethodPatch, repo.FullName, map[string]any{"topics": repo.Topics}, nil)
+}
+
+// repoState 为仓库当前的可见性和状态
+type repoState struct {
+	VisibilityLevel string `json:"visibility_level"`
+	Status          int    `json:"status"`
+}
+
+// repoStatusArchived 为已归档仓库的 status
+const repoStatusArchived = 1
+
+// UpdateRepo 更新仓库描述、主页、主题、可见性和归档状态
+// CNB 暂不支持通过 API 修改默认分支，DefaultBranch 会被忽略
+// Secret 仓库视为私有，期望私有时保持 Secret 不变
+func (p *Platform) UpdateRepo(ctx context.Context, repo *platforms.RepoInfo) error {
+	var state repoState
+	if err := p.request(ctx, http.MethodGet, repo.FullName, nil, &state); err != nil {
+		return err
+	}
+	archived := state.Status == repoStatusArchived
+	// 归档后的仓库无法修改，需先取消归档，最后再归档
+	if archived && !repo.IsArchived {
+		if err := p.request(ctx, http.MethodPost, fmt.Sprintf("%s/-/settings/unarchive", repo.FullName), nil, nil); err != nil {
+			return err
+		}
+	}
+	topics := repo.Topics
+	if topics == nil {
+		topics = []string{}
+	}
+	if err := p.request(ctx, http.MethodPatch, repo.FullName, map[string]any{
+		"description": repo.Description,
+		"site":        repo.Homepage,
+		"topics":      topics,
+	}, nil); err != nil {
+		return err
+	}
+	if isPrivate := state.VisibilityLevel != VisibilityLevelPublic; isPrivate != repo.IsPrivate {
+		visibility := strings.ToLower(VisibilityLevelPublic)
+		if repo.IsPrivate {
+			visibility = strings.ToLower(VisibilityLevelPrivate)
+		}
+		if err := p.request(ctx, http.MethodPost, fmt.Sprintf("%s/-/settings/set_visibility?visibility=%s", repo.FullName, visibility), nil, nil); err != nil {
+			return err
+		}
+	}
+	if repo.IsArchived && !archived {
+		return p.request(ctx, http.MethodPost, fmt.Sprintf("%s/-/settings/archive", repo.FullName), nil, nil)
+	}
+	return nil
 }
 
 func (p *Platform) DeleteRepo(ctx context.Context, repo *platforms.RepoInfo) error {
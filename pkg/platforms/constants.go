@@ -9,6 +9,7 @@ type IPlatform interface {
 	credential.ICredential
 	IPlatformRepo
 	IPlatformReleases
+	IPlatformIssues
 }
 
 // IPlatformRepo 定义了对代码托管平台仓库的操作接口。
@@ -26,6 +27,10 @@ type IPlatformRepo interface {
 	// CreateRepo 创建一个新的仓库。
 	CreateRepo(ctx context.Context, repoInfo *RepoInfo) error
 
+	// UpdateRepo 更新仓库元数据（描述、主页、主题、默认分支、可见性、归档状态）。
+	// repoInfo 中的字段即为期望的最终状态，平台不支持的字段会被忽略。
+	UpdateRepo(ctx context.Context, repoInfo *RepoInfo) error
+
 	// DeleteRepo 删除指定的仓库。
 	DeleteRepo(ctx context.Context, repoInfo *RepoInfo) error
 }
@@ -47,3 +52,150 @@ type IPlatformReleases interface {
 	// UploadReleaseAsset 上传一个或多个资源文件到指定的发布版本
 	UploadReleaseAsset(ctx context.Context, releaseInfo *ReleaseInfo, filenames []string) error
 }
+
+// IPlatformIssues 定义了平台 Issue 及评论相关的操作
+type IPlatformIssues interface {
+	// ListIssues 列出指定仓库的所有 Issue（包含已关闭，不包含合并请求），按创建时间升序
+	ListIssues(ctx context.Context, fullName string) ([]*IssueInfo, error)
+
+	// CreateIssue 在指定仓库下创建 Issue，State 为 IssueStateClosed 时创建后立即关闭
+	CreateIssue(ctx context.Context, fullName string, issue *IssueInfo) (*IssueInfo, error)
+
+	// UpdateIssueState 修改 Issue 的状态（IssueStateOpen 或 IssueStateClosed）
+	UpdateIssueState(ctx context.Context, fullName, number, state string) error
+
+	// ListIssueComments 列出 Issue 下的所有评论，按创建时间升序
+	ListIssueComments(ctx context.Context, fullName, number string) ([]*CommentInfo, error)
+
+	// CreateIssueComment 在 Issue 下创建一条评论
+	CreateIssueComment(ctx context.Context, fullName, number, body string) error
+}
+
+// IPlatformSubOrgs 由支持嵌套组织（子组织/子组）的平台实现，例如 CNB。
+// 未实现该接口的平台在递归同步时会被扁平化处理。
+type IPlatformSubOrgs interface {
+	// ListSubOrgs 列出指定组织下的直接子组织，返回子组织的完整路径，例如 "org/child"。
+	ListSubOrgs(ctx context.Context, orgName string) ([]string, error)
+
+	// CreateSubOrg 在 parent 组织下创建名为 name 的子组织。
+	CreateSubOrg(ctx context.Context, parent, name string) error
+}
+
+// IPlatformDefaultBranch 由支持通过 API 修改默认分支的平台实现。
+type IPlatformDefaultBranch interface {
+	// SetDefaultBranch 将仓库的默认分支设置为 branch，该分支需已存在。
+	SetDefaultBranch(ctx context.Context, fullName, branch string) error
+}
+
+// IPlatformWiki 由支持通过 API 开启仓库 Wiki 的平台实现。
+type IPlatformWiki interface {
+	// EnableWiki 开启仓库的 Wiki 功能，Wiki 内容以 "<repo>.wiki.git" 仓库的形式推送。
+	EnableWiki(ctx context.Context, fullName string) error
+}
+
+// IPlatformPulls 由支持通过 API 读取和创建合并请求的平台实现。
+type IPlatformPulls interface {
+	// ListPulls 返回仓库所有状态的合并请求，按创建时间升序排列。
+	ListPulls(ctx context.Context, fullName string) ([]*PullInfo, error)
+	// ListPullFiles 返回合并请求中变更的文件。
+	ListPullFiles(ctx context.Context, fullName, number string) ([]*PullFileInfo, error)
+	// ListPullComments 返回合并请求的普通评论和代码评审评论，按创建时间升序排列。
+	ListPullComments(ctx context.Context, fullName, number string) ([]*CommentInfo, error)
+	// CreatePull 创建合并请求，pull.State 不为 PullStateOpen 时创建后立即关闭。
+	CreatePull(ctx context.Context, fullName string, pull *PullInfo) (*PullInfo, error)
+	// CreatePullComment 在合并请求下添加一条评论。
+	CreatePullComment(ctx context.Context, fullName, number, body string) error
+}
+
+// IPlatformLabels 由支持通过 API 管理 Issue 标签的平台实现。
+type IPlatformLabels interface {
+	// ListLabels 返回仓库的所有标签。
+	ListLabels(ctx context.Context, fullName string) ([]*LabelInfo, error)
+	// CreateLabel 在仓库中创建标签。
+	CreateLabel(ctx context.Context, fullName string, label *LabelInfo) error
+	// UpdateLabel 更新已有标签的颜色和描述，label.ID 和 label.Name 来自 ListLabels。
+	UpdateLabel(ctx context.Context, fullName string, label *LabelInfo) error
+	// LabelDescription 表示平台是否支持标签描述。
+	LabelDescription() bool
+}
+
+// IPlatformMilestones 由支持通过 API 管理里程碑的平台实现。
+type IPlatformMilestones interface {
+	// ListMilestones 返回仓库所有状态的里程碑。
+	ListMilestones(ctx context.Context, fullName string) ([]*MilestoneInfo, error)
+	// CreateMilestone 在仓库中创建里程碑。
+	CreateMilestone(ctx context.Context, fullName string, milestone *MilestoneInfo) error
+	// UpdateMilestone 更新已有里程碑，milestone.Number 来自 ListMilestones。
+	UpdateMilestone(ctx context.Context, fullName string, milestone *MilestoneInfo) error
+}
+
+// IPlatformHooks 由支持通过 API 管理仓库 Webhook 的平台实现。
+type IPlatformHooks interface {
+	// ListHooks 返回仓库的所有 Webhook，事件已转换为统一名称。
+	ListHooks(ctx context.Context, fullName string) ([]*HookInfo, error)
+	// CreateHook 在仓库中创建 Webhook，平台不支持的事件会被忽略。
+	CreateHook(ctx context.Context, fullName string, hook *HookInfo) error
+	// UpdateHook 更新已有 Webhook，hook.ID 来自 ListHooks，hook.Secret 为空时保留原密钥。
+	UpdateHook(ctx context.Context, fullName string, hook *HookInfo) error
+	// DeleteHook 删除仓库中的 Webhook。
+	DeleteHook(ctx context.Context, fullName string, id int64) error
+	// HookEvents 返回平台支持的统一事件名称。
+	HookEvents() []string
+}
+
+// IPlatformProtection 由支持通过 API 管理分支保护的平台实现。
+type IPlatformProtection interface {
+	// ListProtections 返回仓库的分支保护规则。
+	ListProtections(ctx context.Context, fullName string) ([]*ProtectionRule, error)
+	// SetProtection 创建或更新 rule.Branch 对应的分支保护规则。
+	SetProtection(ctx context.Context, fullName string, rule *ProtectionRule) error
+}
+
+// IPlatformCollaborators 由支持管理仓库协作者的平台实现。
+type IPlatformCollaborators interface {
+	// ListCollaborators 返回仓库直接添加的协作者及其权限。
+	ListCollaborators(ctx context.Context, fullName string) ([]*CollaboratorInfo, error)
+	// AddCollaborator 添加协作者或修改已有协作者的权限。
+	AddCollaborator(ctx context.Context, fullName string, collaborator *CollaboratorInfo) error
+}
+
+// IPlatformTeams 由支持组织团队的平台实现。
+type IPlatformTeams interface {
+	// ListTeams 返回组织的团队及其成员和仓库。
+	ListTeams(ctx context.Context, org string) ([]*TeamInfo, error)
+	// CreateTeam 在组织中创建团队并返回平台中的团队信息。
+	CreateTeam(ctx context.Context, org string, team *TeamInfo) (*TeamInfo, error)
+	// AddTeamMember 将用户加入团队。
+	AddTeamMember(ctx context.Context, org string, team *TeamInfo, username string) error
+	// AddTeamRepo 授予团队访问组织仓库的权限。
+	AddTeamRepo(ctx context.Context, org string, team *TeamInfo, repo string) error
+}
+
+// IPlatformDeployKeys 由支持管理仓库部署公钥的平台实现。
+type IPlatformDeployKeys interface {
+	// ListDeployKeys 返回仓库的部署公钥。
+	ListDeployKeys(ctx context.Context, fullName string) ([]*DeployKeyInfo, error)
+	// AddDeployKey 为仓库添加部署公钥。
+	AddDeployKey(ctx context.Context, fullName string, key *DeployKeyInfo) error
+	// RemoveDeployKey 删除仓库中指定 ID 的部署公钥。
+	RemoveDeployKey(ctx context.Context, fullName string, id int64) error
+}
+
+// IPlatformOrgs 由支持查询和创建组织的平台实现。
+type IPlatformOrgs interface {
+	// GetOrg 返回组织信息，组织不存在时返回的错误满足 IsNotFound。
+	GetOrg(ctx context.Context, name string) (*OrgInfo, error)
+	// CreateOrg 创建组织，当前用户成为组织所有者；平台不支持通过 API 创建时返回 errors.ErrUnsupported。
+	CreateOrg(ctx context.Context, org *OrgInfo) error
+	// CurrentUser 返回凭证对应的用户名，用于判断目标命名空间是否为当前用户自己。
+	CurrentUser(ctx context.Context) (string, error)
+}
+
+// IPlatformServerMirror 由支持服务端拉取镜像的平台实现，例如 Gitea/Forgejo。
+// 镜像仓库由目标平台定期从源仓库拉取，无需在本地克隆和推送。
+type IPlatformServerMirror interface {
+	// CreateMirror 创建按 mirror.Interval 从 mirror.CloneURL 拉取的镜像仓库。
+	CreateMirror(ctx context.Context, repo *RepoInfo, mirror *MirrorInfo) error
+	// SyncMirror 触发镜像仓库立即同步。
+	SyncMirror(ctx context.Context, fullName string) error
+}
@@ -0,0 +1,54 @@
+package platforms
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// DeployKeyInfo 表示仓库的部署公钥
+type DeployKeyInfo struct {
+	ID       int64  `json:"-"`                   // 公钥在平台中的 ID，仅用于删除
+	Title    string `json:"title"`               // 公钥标题
+	Key      string `json:"key"`                 // OpenSSH 格式的公钥
+	ReadOnly *bool  `json:"read_only,omitempty"` // 是否只读，默认为只读
+}
+
+// IsReadOnly 返回公钥是否只读，未设置时视为只读
+func (dk *DeployKeyInfo) IsReadOnly() bool {
+	return dk.ReadOnly == nil || *dk.ReadOnly
+}
+
+// Fingerprint 返回去掉注释后的公钥，平台间按此匹配同一把公钥
+func (dk *DeployKeyInfo) Fingerprint() string {
+	fields := strings.Fields(dk.Key)
+	if len(fields) >= 2 {
+		return fields[0] + " " + fields[1]
+	}
+	return strings.TrimSpace(dk.Key)
+}
+
+// LoadDeployKeys 从 JSON 文件读取部署公钥
+// Examples:
+//
+//	[{"title": "deploy@prod", "key": "ssh-ed25519 AAAA... deploy@prod", "read_only": true}]
+func LoadDeployKeys(path string) ([]*DeployKeyInfo, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var keys []*DeployKeyInfo
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("parse deploy keys %s: %w", path, err)
+	}
+	for i, key := range keys {
+		if len(strings.Fields(key.Key)) < 2 {
+			return nil, fmt.Errorf("deploy keys %s: entry %d is not an OpenSSH public key", path, i)
+		}
+		if key.Title == "" {
+			key.Title = fmt.Sprintf("mpgrm-%d", i+1)
+		}
+	}
+	return keys, nil
+}
@@ -0,0 +1,48 @@
+package platforms
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLoadDeployKeys(t *testing.T) {
+	writable := false
+	tests := []struct {
+		name    string
+		content string
+		want    []*DeployKeyInfo
+		wantErr bool
+	}{
+		{
+			name:    "keys",
+			content: `[{"title": "deploy@prod", "key": "ssh-ed25519 AAAAC3 deploy@prod", "read_only": false}, {"key": "ssh-rsa AAAAB3"}]`,
+			want: []*DeployKeyInfo{
+				{Title: "deploy@prod", Key: "ssh-ed25519 AAAAC3 deploy@prod", ReadOnly: &writable},
+				{Title: "mpgrm-2", Key: "ssh-rsa AAAAB3"},
+			},
+		},
+		{name: "not a public key", content: `[{"title": "bad", "key": "AAAAC3"}]`, wantErr: true},
+		{name: "invalid json", content: `[{"key": 1}]`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LoadDeployKeys(writeFile(t, tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadDeployKeys() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LoadDeployKeys() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeployKeyInfo(t *testing.T) {
+	key := &DeployKeyInfo{Key: " ssh-ed25519 AAAAC3 deploy@prod\n"}
+	if got := key.Fingerprint(); got != "ssh-ed25519 AAAAC3" {
+		t.Errorf("Fingerprint() = %q, want the key without comment", got)
+	}
+	if !key.IsReadOnly() {
+		t.Error("IsReadOnly() = false, want true by default")
+	}
+}
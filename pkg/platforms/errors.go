@@ -0,0 +1,20 @@
+package platforms
+
+import (
+	"errors"
+	"github.com/chihqiang/mpgrm/pkg/httpx"
+	"net/http"
+)
+
+// ErrNotFound 表示请求的仓库、组织或发布版本不存在
+var ErrNotFound = errors.New("not found")
+
+// IsNotFound 判断 err 是否表示资源不存在，包括包装了 ErrNotFound 的错误和返回 404 的 httpx 请求
+// 其它错误（网络错误、权限不足、限流等）不代表资源不存在
+func IsNotFound(err error) bool {
+	if errors.Is(err, ErrNotFound) {
+		return true
+	}
+	var statusErr *httpx.StatusError
+	return errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,137 @@
+package gitea
+
+import (
+	"code.gitea.io/sdk/gitea"
+	"context"
+	"github.com/chihqiang/mpgrm/pkg/httpx"
+	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"github.com/chihqiang/mpgrm/pkg/x"
+	"slices"
+)
+
+// Gitea 以 create 事件表示标签（和分支）的创建
+var hookEvents = map[string]string{
+	platforms.HookEventPush:         "push",
+	platforms.HookEventTagPush:      "create",
+	platforms.HookEventIssues:       "issues",
+	platforms.HookEventIssueComment: "issue_comment",
+	platforms.HookEventPullRequest:  "pull_request",
+	platforms.HookEventRelease:      "release",
+}
+
+func (p *Platform) ListHooks(ctx context.Context, fullName string) ([]*platforms.HookInfo, error) {
+	client, err := p.GetClient()
+	if err != nil {
+		return nil, err
+	}
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return nil, err
+	}
+	var hooks []*platforms.HookInfo
+	err = httpx.Paginate[*gitea.Hook](func(page int) ([]*gitea.Hook, error) {
+		items, _, err := client.ListRepoHooks(owner, repo, gitea.ListHooksOptions{
+			ListOptions: gitea.ListOptions{
+				Page:     page,
+				PageSize: 50,
+			},
+		})
+		return items, err
+	}, func(hook *gitea.Hook) {
+		active := hook.Active
+		info := &platforms.HookInfo{
+			ID:          hook.ID,
+			URL:         hook.Config["url"],
+			ContentType: hook.Config["content_type"],
+			Active:      &active,
+		}
+		for event, name := range hookEvents {
+			if slices.Contains(hook.Events, name) {
+				info.Events = append(info.Events, event)
+			}
+		}
+		hooks = append(hooks, info)
+	})
+	return hooks, err
+}
+
+func (p *Platform) CreateHook(ctx context.Context, fullName string, hook *platforms.HookInfo) error {
+	client, err := p.GetClient()
+	if err != nil {
+		return err
+	}
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return err
+	}
+	_, _, err = client.CreateRepoHook(owner, repo, gitea.CreateHookOption{
+		Type:   gitea.HookTypeGitea,
+		Config: hookConfig(hook),
+		Events: toHookEvents(hook.Events),
+		Active: hook.IsActive(),
+	})
+	return err
+}
+
+func (p *Platform) UpdateHook(ctx context.Context, fullName string, hook *platforms.HookInfo) error {
+	client, err := p.GetClient()
+	if err != nil {
+		return err
+	}
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return err
+	}
+	active := hook.IsActive()
+	_, err = client.EditRepoHook(owner, repo, hook.ID, gitea.EditHookOption{
+		Config: hookConfig(hook),
+		Events: toHookEvents(hook.Events),
+		Active: &active,
+	})
+	return err
+}
+
+func (p *Platform) DeleteHook(ctx context.Context, fullName string, id int64) error {
+	client, err := p.GetClient()
+	if err != nil {
+		return err
+	}
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return err
+	}
+	_, err = client.DeleteRepoHook(owner, repo, id)
+	return err
+}
+
+func (p *Platform) HookEvents() []string {
+	return []string{
+		platforms.HookEventPush,
+		platforms.HookEventTagPush,
+		platforms.HookEventIssues,
+		platforms.HookEventIssueComment,
+		platforms.HookEventPullRequest,
+		platforms.HookEventRelease,
+	}
+}
+
+func hookConfig(hook *platforms.HookInfo) map[string]string {
+	config := map[string]string{
+		"url":          hook.URL,
+		"content_type": hook.GetContentType(),
+	}
+	if hook.Secret != "" {
+		config["secret"] = hook.Secret
+	}
+	return config
+}
+
+func toHookEvents(events []string) []string {
+	var names []string
+	for _, event := range events {
+		if name, ok := hookEvents[event]; ok && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,193 @@
+package gitea
+
+import (
+	"code.gitea.io/sdk/gitea"
+	"context"
+	"github.com/chihqiang/mpgrm/pkg/httpx"
+	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"github.com/chihqiang/mpgrm/pkg/x"
+	"sort"
+	"strconv"
+)
+
+func (p *Platform) ListIssues(ctx context.Context, fullName string) ([]*platforms.IssueInfo, error) {
+	client, err := p.GetClient()
+	if err != nil {
+		return nil, err
+	}
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return nil, err
+	}
+	var issues []*platforms.IssueInfo
+	err = httpx.Paginate[*gitea.Issue](func(page int) ([]*gitea.Issue, error) {
+		items, _, err := client.ListRepoIssues(owner, repo, gitea.ListIssueOption{
+			ListOptions: gitea.ListOptions{
+				Page:     page,
+				PageSize: 20,
+			},
+			State: gitea.StateAll,
+			Type:  gitea.IssueTypeIssue,
+		})
+		return items, err
+	}, func(issue *gitea.Issue) {
+		issues = append(issues, toIssueInfo(issue))
+	})
+	// Gitea 默认按创建时间倒序返回
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].CreatedAt.Before(issues[j].CreatedAt)
+	})
+	return issues, err
+}
+
+func (p *Platform) CreateIssue(ctx context.Context, fullName string, issue *platforms.IssueInfo) (*platforms.IssueInfo, error) {
+	client, err := p.GetClient()
+	if err != nil {
+		return nil, err
+	}
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return nil, err
+	}
+	labelIDs, err := p.labelIDs(owner, repo, issue.Labels)
+	if err != nil {
+		return nil, err
+	}
+	created, _, err := client.CreateIssue(owner, repo, gitea.CreateIssueOption{
+		Title:     issue.Title,
+		Body:      issue.Body,
+		Assignees: issue.Assignees,
+		Labels:    labelIDs,
+		Closed:    issue.State == platforms.IssueStateClosed,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toIssueInfo(created), nil
+}
+
+func (p *Platform) UpdateIssueState(ctx context.Context, fullName, number, state string) error {
+	client, err := p.GetClient()
+	if err != nil {
+		return err
+	}
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return err
+	}
+	index, err := strconv.ParseInt(number, 10, 64)
+	if err != nil {
+		return err
+	}
+	stateType := gitea.StateType(state)
+	_, _, err = client.EditIssue(owner, repo, index, gitea.EditIssueOption{State: &stateType})
+	return err
+}
+
+func (p *Platform) ListIssueComments(ctx context.Context, fullName, number string) ([]*platforms.CommentInfo, error) {
+	client, err := p.GetClient()
+	if err != nil {
+		return nil, err
+	}
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return nil, err
+	}
+	index, err := strconv.ParseInt(number, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	var comments []*platforms.CommentInfo
+	err = httpx.Paginate[*gitea.Comment](func(page int) ([]*gitea.Comment, error) {
+		items, _, err := client.ListIssueComments(owner, repo, index, gitea.ListIssueCommentOptions{
+			ListOptions: gitea.ListOptions{
+				Page:     page,
+				PageSize: 20,
+			},
+		})
+		return items, err
+	}, func(comment *gitea.Comment) {
+		info := &platforms.CommentInfo{
+			ID:        comment.ID,
+			Body:      comment.Body,
+			CreatedAt: comment.Created,
+			URL:       comment.HTMLURL,
+		}
+		if comment.Poster != nil {
+			info.Author = comment.Poster.UserName
+		}
+		comments = append(comments, info)
+	})
+	return comments, err
+}
+
+func (p *Platform) CreateIssueComment(ctx context.Context, fullName, number, body string) error {
+	client, err := p.GetClient()
+	if err != nil {
+		return err
+	}
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return err
+	}
+	index, err := strconv.ParseInt(number, 10, 64)
+	if err != nil {
+		return err
+	}
+	_, _, err = client.CreateIssueComment(owner, repo, index, gitea.CreateIssueCommentOption{Body: body})
+	return err
+}
+
+// labelIDs 将标签名称转换为仓库中已存在标签的 ID，不存在的标签会被忽略
+func (p *Platform) labelIDs(owner, repo string, names []string) ([]int64, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	client, err := p.GetClient()
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]int64)
+	err = httpx.Paginate[*gitea.Label](func(page int) ([]*gitea.Label, error) {
+		labels, _, err := client.ListRepoLabels(owner, repo, gitea.ListLabelsOptions{
+			ListOptions: gitea.ListOptions{
+				Page:     page,
+				PageSize: 50,
+			},
+		})
+		return labels, err
+	}, func(label *gitea.Label) {
+		existing[label.Name] = label.ID
+	})
+	if err != nil {
+		return nil, err
+	}
+	var ids []int64
+	for _, name := range names {
+		if id, ok := existing[name]; ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func toIssueInfo(issue *gitea.Issue) *platforms.IssueInfo {
+	info := &platforms.IssueInfo{
+		Number:    strconv.FormatInt(issue.Index, 10),
+		Title:     issue.Title,
+		Body:      issue.Body,
+		State:     string(issue.State),
+		CreatedAt: issue.Created,
+		URL:       issue.HTMLURL,
+	}
+	if issue.Poster != nil {
+		info.Author = issue.Poster.UserName
+	}
+	for _, label := range issue.Labels {
+		info.Labels = append(info.Labels, label.Name)
+	}
+	for _, assignee := range issue.Assignees {
+		info.Assignees = append(info.Assignees, assignee.UserName)
+	}
+	return info
+}
@@ -0,0 +1,69 @@
+package gitea
+
+import (
+	"code.gitea.io/sdk/gitea"
+	"context"
+	"github.com/chihqiang/mpgrm/pkg/httpx"
+	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"github.com/chihqiang/mpgrm/pkg/x"
+)
+
+func (p *Platform) ListDeployKeys(ctx context.Context, fullName string) ([]*platforms.DeployKeyInfo, error) {
+	client, err := p.GetClient()
+	if err != nil {
+		return nil, err
+	}
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return nil, err
+	}
+	var keys []*platforms.DeployKeyInfo
+	err = httpx.Paginate[*gitea.DeployKey](func(page int) ([]*gitea.DeployKey, error) {
+		items, _, err := client.ListDeployKeys(owner, repo, gitea.ListDeployKeysOptions{
+			ListOptions: gitea.ListOptions{
+				Page:     page,
+				PageSize: 50,
+			},
+		})
+		return items, err
+	}, func(key *gitea.DeployKey) {
+		readOnly := key.ReadOnly
+		keys = append(keys, &platforms.DeployKeyInfo{
+			ID:       key.ID,
+			Title:    key.Title,
+			Key:      key.Key,
+			ReadOnly: &readOnly,
+		})
+	})
+	return keys, err
+}
+
+func (p *Platform) AddDeployKey(ctx context.Context, fullName string, key *platforms.DeployKeyInfo) error {
+	client, err := p.GetClient()
+	if err != nil {
+		return err
+	}
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return err
+	}
+	_, _, err = client.CreateDeployKey(owner, repo, gitea.CreateKeyOption{
+		Title:    key.Title,
+		Key:      key.Key,
+		ReadOnly: key.IsReadOnly(),
+	})
+	return err
+}
+
+func (p *Platform) RemoveDeployKey(ctx context.Context, fullName string, id int64) error {
+	client, err := p.GetClient()
+	if err != nil {
+		return err
+	}
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return err
+	}
+	_, err = client.DeleteDeployKey(owner, repo, id)
+	return err
+}
@@ -0,0 +1,157 @@
+package gitea
+
+import (
+	"code.gitea.io/sdk/gitea"
+	"context"
+	"github.com/chihqiang/mpgrm/pkg/httpx"
+	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"github.com/chihqiang/mpgrm/pkg/x"
+	"strconv"
+)
+
+func (p *Platform) ListLabels(ctx context.Context, fullName string) ([]*platforms.LabelInfo, error) {
+	client, err := p.GetClient()
+	if err != nil {
+		return nil, err
+	}
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return nil, err
+	}
+	var labels []*platforms.LabelInfo
+	err = httpx.Paginate[*gitea.Label](func(page int) ([]*gitea.Label, error) {
+		items, _, err := client.ListRepoLabels(owner, repo, gitea.ListLabelsOptions{
+			ListOptions: gitea.ListOptions{
+				Page:     page,
+				PageSize: 50,
+			},
+		})
+		return items, err
+	}, func(label *gitea.Label) {
+		labels = append(labels, &platforms.LabelInfo{
+			ID:          label.ID,
+			Name:        label.Name,
+			Color:       platforms.NormalizeColor(label.Color),
+			Description: label.Description,
+		})
+	})
+	return labels, err
+}
+
+func (p *Platform) CreateLabel(ctx context.Context, fullName string, label *platforms.LabelInfo) error {
+	client, err := p.GetClient()
+	if err != nil {
+		return err
+	}
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return err
+	}
+	_, _, err = client.CreateLabel(owner, repo, gitea.CreateLabelOption{
+		Name:        label.Name,
+		Color:       "#" + platforms.NormalizeColor(label.Color),
+		Description: label.Description,
+	})
+	return err
+}
+
+func (p *Platform) UpdateLabel(ctx context.Context, fullName string, label *platforms.LabelInfo) error {
+	client, err := p.GetClient()
+	if err != nil {
+		return err
+	}
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return err
+	}
+	color := "#" + platforms.NormalizeColor(label.Color)
+	_, _, err = client.EditLabel(owner, repo, label.ID, gitea.EditLabelOption{
+		Color:       &color,
+		Description: &label.Description,
+	})
+	return err
+}
+
+func (p *Platform) LabelDescription() bool {
+	return true
+}
+
+func (p *Platform) ListMilestones(ctx context.Context, fullName string) ([]*platforms.MilestoneInfo, error) {
+	client, err := p.GetClient()
+	if err != nil {
+		return nil, err
+	}
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return nil, err
+	}
+	var milestones []*platforms.MilestoneInfo
+	err = httpx.Paginate[*gitea.Milestone](func(page int) ([]*gitea.Milestone, error) {
+		items, _, err := client.ListRepoMilestones(owner, repo, gitea.ListMilestoneOption{
+			ListOptions: gitea.ListOptions{
+				Page:     page,
+				PageSize: 50,
+			},
+			State: gitea.StateAll,
+		})
+		return items, err
+	}, func(milestone *gitea.Milestone) {
+		info := &platforms.MilestoneInfo{
+			Number:      strconv.FormatInt(milestone.ID, 10),
+			Title:       milestone.Title,
+			Description: milestone.Description,
+			State:       string(milestone.State),
+		}
+		if milestone.Deadline != nil {
+			info.DueOn = *milestone.Deadline
+		}
+		milestones = append(milestones, info)
+	})
+	return milestones, err
+}
+
+func (p *Platform) CreateMilestone(ctx context.Context, fullName string, milestone *platforms.MilestoneInfo) error {
+	client, err := p.GetClient()
+	if err != nil {
+		return err
+	}
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return err
+	}
+	opt := gitea.CreateMilestoneOption{
+		Title:       milestone.Title,
+		Description: milestone.Description,
+		State:       gitea.StateType(milestone.State),
+	}
+	if !milestone.DueOn.IsZero() {
+		opt.Deadline = &milestone.DueOn
+	}
+	_, _, err = client.CreateMilestone(owner, repo, opt)
+	return err
+}
+
+func (p *Platform) UpdateMilestone(ctx context.Context, fullName string, milestone *platforms.MilestoneInfo) error {
+	client, err := p.GetClient()
+	if err != nil {
+		return err
+	}
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return err
+	}
+	id, err := strconv.ParseInt(milestone.Number, 10, 64)
+	if err != nil {
+		return err
+	}
+	state := gitea.StateType(milestone.State)
+	opt := gitea.EditMilestoneOption{
+		Description: &milestone.Description,
+		State:       &state,
+	}
+	if !milestone.DueOn.IsZero() {
+		opt.Deadline = &milestone.DueOn
+	}
+	_, _, err = client.EditMilestone(owner, repo, id, opt)
+	return err
+}
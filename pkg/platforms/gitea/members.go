@@ -0,0 +1,193 @@
+package gitea
+
+import (
+	"code.gitea.io/sdk/gitea"
+	"context"
+	"github.com/chihqiang/mpgrm/pkg/httpx"
+	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"github.com/chihqiang/mpgrm/pkg/x"
+)
+
+// fromAccessMode 将 Gitea 的访问级别转换为统一权限
+func fromAccessMode(mode gitea.AccessMode) string {
+	switch mode {
+	case gitea.AccessModeAdmin, gitea.AccessModeOwner:
+		return platforms.PermissionAdmin
+	case gitea.AccessModeWrite:
+		return platforms.PermissionWrite
+	default:
+		return platforms.PermissionRead
+	}
+}
+
+// toAccessMode 将统一权限转换为 Gitea 的访问级别
+func toAccessMode(permission string) gitea.AccessMode {
+	switch permission {
+	case platforms.PermissionAdmin:
+		return gitea.AccessModeAdmin
+	case platforms.PermissionWrite:
+		return gitea.AccessModeWrite
+	default:
+		return gitea.AccessModeRead
+	}
+}
+
+func (p *Platform) ListCollaborators(ctx context.Context, fullName string) ([]*platforms.CollaboratorInfo, error) {
+	client, err := p.GetClient()
+	if err != nil {
+		return nil, err
+	}
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return nil, err
+	}
+	var users []*gitea.User
+	err = httpx.Paginate[*gitea.User](func(page int) ([]*gitea.User, error) {
+		items, _, err := client.ListCollaborators(owner, repo, gitea.ListCollaboratorsOptions{
+			ListOptions: gitea.ListOptions{
+				Page:     page,
+				PageSize: 50,
+			},
+		})
+		return items, err
+	}, func(user *gitea.User) {
+		users = append(users, user)
+	})
+	if err != nil {
+		return nil, err
+	}
+	// 协作者列表不包含权限，需要逐个查询
+	collaborators := make([]*platforms.CollaboratorInfo, 0, len(users))
+	for _, user := range users {
+		result, _, err := client.CollaboratorPermission(owner, repo, user.UserName)
+		if err != nil {
+			return nil, err
+		}
+		collaborators = append(collaborators, &platforms.CollaboratorInfo{
+			Username:   user.UserName,
+			Permission: fromAccessMode(result.Permission),
+		})
+	}
+	return collaborators, nil
+}
+
+func (p *Platform) AddCollaborator(ctx context.Context, fullName string, collaborator *platforms.CollaboratorInfo) error {
+	client, err := p.GetClient()
+	if err != nil {
+		return err
+	}
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return err
+	}
+	mode := toAccessMode(collaborator.Permission)
+	_, err = client.AddCollaborator(owner, repo, collaborator.Username, gitea.AddCollaboratorOption{
+		Permission: &mode,
+	})
+	return err
+}
+
+func (p *Platform) ListTeams(ctx context.Context, org string) ([]*platforms.TeamInfo, error) {
+	client, err := p.GetClient()
+	if err != nil {
+		return nil, err
+	}
+	var teams []*platforms.TeamInfo
+	err = httpx.Paginate[*gitea.Team](func(page int) ([]*gitea.Team, error) {
+		items, _, err := client.ListOrgTeams(org, gitea.ListTeamsOptions{
+			ListOptions: gitea.ListOptions{
+				Page:     page,
+				PageSize: 50,
+			},
+		})
+		return items, err
+	}, func(team *gitea.Team) {
+		teams = append(teams, &platforms.TeamInfo{
+			ID:          team.ID,
+			Name:        team.Name,
+			Description: team.Description,
+			Permission:  fromAccessMode(team.Permission),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, team := range teams {
+		err = httpx.Paginate[*gitea.User](func(page int) ([]*gitea.User, error) {
+			items, _, err := client.ListTeamMembers(team.ID, gitea.ListTeamMembersOptions{
+				ListOptions: gitea.ListOptions{
+					Page:     page,
+					PageSize: 50,
+				},
+			})
+			return items, err
+		}, func(user *gitea.User) {
+			team.Members = append(team.Members, user.UserName)
+		})
+		if err != nil {
+			return nil, err
+		}
+		err = httpx.Paginate[*gitea.Repository](func(page int) ([]*gitea.Repository, error) {
+			items, _, err := client.ListTeamRepositories(team.ID, gitea.ListTeamRepositoriesOptions{
+				ListOptions: gitea.ListOptions{
+					Page:     page,
+					PageSize: 50,
+				},
+			})
+			return items, err
+		}, func(repo *gitea.Repository) {
+			team.Repos = append(team.Repos, repo.Name)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return teams, nil
+}
+
+// CreateTeam 创建的团队可以访问仓库的全部功能单元
+func (p *Platform) CreateTeam(ctx context.Context, org string, team *platforms.TeamInfo) (*platforms.TeamInfo, error) {
+	client, err := p.GetClient()
+	if err != nil {
+		return nil, err
+	}
+	created, _, err := client.CreateTeam(org, gitea.CreateTeamOption{
+		Name:        team.Name,
+		Description: team.Description,
+		Permission:  toAccessMode(team.Permission),
+		Units: []gitea.RepoUnitType{
+			gitea.RepoUnitCode,
+			gitea.RepoUnitIssues,
+			gitea.RepoUnitPulls,
+			gitea.RepoUnitReleases,
+			gitea.RepoUnitWiki,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &platforms.TeamInfo{
+		ID:          created.ID,
+		Name:        created.Name,
+		Description: created.Description,
+		Permission:  fromAccessMode(created.Permission),
+	}, nil
+}
+
+func (p *Platform) AddTeamMember(ctx context.Context, org string, team *platforms.TeamInfo, username string) error {
+	client, err := p.GetClient()
+	if err != nil {
+		return err
+	}
+	_, err = client.AddTeamMember(team.ID, username)
+	return err
+}
+
+func (p *Platform) AddTeamRepo(ctx context.Context, org string, team *platforms.TeamInfo, repo string) error {
+	client, err := p.GetClient()
+	if err != nil {
+		return err
+	}
+	_, err = client.AddTeamRepository(team.ID, org, repo)
+	return err
+}
@@ -0,0 +1,47 @@
+package gitea
+
+import (
+	"code.gitea.io/sdk/gitea"
+	"context"
+	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"github.com/chihqiang/mpgrm/pkg/x"
+)
+
+// CreateMirror 通过迁移接口创建拉取镜像，源仓库按普通 Git 仓库访问
+func (p *Platform) CreateMirror(ctx context.Context, repo *platforms.RepoInfo, mirror *platforms.MirrorInfo) error {
+	client, err := p.GetClient()
+	if err != nil {
+		return err
+	}
+	owner, _ := repo.GetOrgName()
+	if owner == "" {
+		owner = p.Credential.Username
+	}
+	_, _, err = client.MigrateRepo(gitea.MigrateRepoOption{
+		RepoName:       repo.Name,
+		RepoOwner:      owner,
+		CloneAddr:      mirror.CloneURL,
+		Service:        gitea.GitServicePlain,
+		AuthUsername:   mirror.Username,
+		AuthPassword:   mirror.Password,
+		Mirror:         true,
+		MirrorInterval: mirror.Interval.String(),
+		Private:        repo.IsPrivate,
+		Description:    repo.Description,
+		Wiki:           mirror.Wiki,
+	})
+	return err
+}
+
+func (p *Platform) SyncMirror(ctx context.Context, fullName string) error {
+	client, err := p.GetClient()
+	if err != nil {
+		return err
+	}
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return err
+	}
+	_, err = client.MirrorSync(owner, repo)
+	return err
+}
@@ -0,0 +1,61 @@
+package gitea
+
+import (
+	"code.gitea.io/sdk/gitea"
+	"context"
+	"fmt"
+	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"net/http"
+)
+
+func (p *Platform) GetOrg(ctx context.Context, name string) (*platforms.OrgInfo, error) {
+	client, err := p.GetClient()
+	if err != nil {
+		return nil, err
+	}
+	org, resp, err := client.GetOrg(name)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("%w: %w", platforms.ErrNotFound, err)
+		}
+		return nil, err
+	}
+	return &platforms.OrgInfo{
+		Name:        org.UserName,
+		DisplayName: org.FullName,
+		Description: org.Description,
+		Homepage:    org.Website,
+		IsPrivate:   org.Visibility == string(gitea.VisibleTypePrivate),
+	}, nil
+}
+
+func (p *Platform) CreateOrg(ctx context.Context, org *platforms.OrgInfo) error {
+	client, err := p.GetClient()
+	if err != nil {
+		return err
+	}
+	visibility := gitea.VisibleTypePublic
+	if org.IsPrivate {
+		visibility = gitea.VisibleTypePrivate
+	}
+	_, _, err = client.CreateOrg(gitea.CreateOrgOption{
+		Name:        org.Name,
+		FullName:    org.DisplayName,
+		Description: org.Description,
+		Website:     org.Homepage,
+		Visibility:  visibility,
+	})
+	return err
+}
+
+func (p *Platform) CurrentUser(ctx context.Context) (string, error) {
+	client, err := p.GetClient()
+	if err != nil {
+		return "", err
+	}
+	user, _, err := client.GetMyUserInfo()
+	if err != nil {
+		return "", err
+	}
+	return user.UserName, nil
+}
@@ -2,8 +2,13 @@ package gitea
 
 import (
 	"code.gitea.io/sdk/gitea"
+	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/chihqiang/mpgrm/pkg/credential"
+	"github.com/chihqiang/mpgrm/pkg/httpx"
+	"net/http"
+	"strings"
 )
 
 const (
@@ -30,5 +35,22 @@ func (p *Platform) GetClient() (*gitea.Client, error) {
 	if p.ApiURL == "" {
 		p.ApiURL = ApiURL
 	}
-	return gitea.NewClient(p.ApiURL, gitea.SetToken(p.Credential.Username))
+	return gitea.NewClient(p.ApiURL, gitea.SetToken(p.Credential.Username), gitea.SetHTTPClient(httpx.Client()))
+}
+
+// getJSON 直接调用 Gitea API v1 并将响应 JSON 解码到 d，用于 SDK 结构体未包含的字段
+func (p *Platform) getJSON(ctx context.Context, route string, d any) error {
+	if p.ApiURL == "" {
+		p.ApiURL = ApiURL
+	}
+	apiURL := strings.TrimSuffix(p.ApiURL, "/") + "/api/v1/" + strings.TrimPrefix(route, "/")
+	resp, err := httpx.Request(ctx, http.MethodGet, apiURL, nil, map[string]string{
+		"Accept":        "application/json",
+		"Authorization": "token " + p.Credential.Token,
+	})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	return json.NewDecoder(resp.Body).Decode(d)
 }
@@ -0,0 +1,88 @@
+package gitea
+
+import (
+	"code.gitea.io/sdk/gitea"
+	"context"
+	"github.com/chihqiang/mpgrm/pkg/httpx"
+	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"github.com/chihqiang/mpgrm/pkg/x"
+)
+
+func (p *Platform) ListProtections(ctx context.Context, fullName string) ([]*platforms.ProtectionRule, error) {
+	client, err := p.GetClient()
+	if err != nil {
+		return nil, err
+	}
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return nil, err
+	}
+	var rules []*platforms.ProtectionRule
+	err = httpx.Paginate[*gitea.BranchProtection](func(page int) ([]*gitea.BranchProtection, error) {
+		items, _, err := client.ListBranchProtections(owner, repo, gitea.ListBranchProtectionsOptions{
+			ListOptions: gitea.ListOptions{
+				Page:     page,
+				PageSize: 50,
+			},
+		})
+		return items, err
+	}, func(protection *gitea.BranchProtection) {
+		branch := protection.RuleName
+		if branch == "" {
+			branch = protection.BranchName
+		}
+		// Gitea 受保护分支始终禁止强制推送和删除
+		rules = append(rules, &platforms.ProtectionRule{
+			Branch:              branch,
+			RequiredReviews:     int(protection.RequiredApprovals),
+			DismissStaleReviews: protection.DismissStaleApprovals,
+			StatusChecks:        protection.StatusCheckContexts,
+			RestrictPush:        !protection.EnablePush || protection.EnablePushWhitelist,
+			PushUsers:           protection.PushWhitelistUsernames,
+			PushTeams:           protection.PushWhitelistTeams,
+			BlockForcePush:      true,
+			BlockDeletion:       true,
+		})
+	})
+	return rules, err
+}
+
+func (p *Platform) SetProtection(ctx context.Context, fullName string, rule *platforms.ProtectionRule) error {
+	client, err := p.GetClient()
+	if err != nil {
+		return err
+	}
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return err
+	}
+	// 需要评审且未指定推送白名单时，只能通过合并请求修改分支
+	enablePush := rule.RestrictPush || rule.RequiredReviews == 0
+	enableStatusCheck := len(rule.StatusChecks) > 0
+	requiredApprovals := int64(rule.RequiredReviews)
+	if _, _, err := client.GetBranchProtection(owner, repo, rule.Branch); err == nil {
+		_, _, err = client.EditBranchProtection(owner, repo, rule.Branch, gitea.EditBranchProtectionOption{
+			EnablePush:             &enablePush,
+			EnablePushWhitelist:    &rule.RestrictPush,
+			PushWhitelistUsernames: rule.PushUsers,
+			PushWhitelistTeams:     rule.PushTeams,
+			EnableStatusCheck:      &enableStatusCheck,
+			StatusCheckContexts:    rule.StatusChecks,
+			RequiredApprovals:      &requiredApprovals,
+			DismissStaleApprovals:  &rule.DismissStaleReviews,
+		})
+		return err
+	}
+	_, _, err = client.CreateBranchProtection(owner, repo, gitea.CreateBranchProtectionOption{
+		RuleName:               rule.Branch,
+		EnablePush:             enablePush,
+		EnablePushWhitelist:    rule.RestrictPush,
+		PushWhitelistUsernames: rule.PushUsers,
+		PushWhitelistTeams:     rule.PushTeams,
+		EnableStatusCheck:      enableStatusCheck,
+		StatusCheckContexts:    rule.StatusChecks,
+		RequiredApprovals:      requiredApprovals,
+		DismissStaleApprovals:  rule.DismissStaleReviews,
+	})
+	return err
+}
@@ -0,0 +1,193 @@
+package gitea
+
+import (
+	"code.gitea.io/sdk/gitea"
+	"context"
+	"github.com/chihqiang/mpgrm/pkg/httpx"
+	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"github.com/chihqiang/mpgrm/pkg/x"
+	"sort"
+	"strconv"
+)
+
+func (p *Platform) ListPulls(ctx context.Context, fullName string) ([]*platforms.PullInfo, error) {
+	client, err := p.GetClient()
+	if err != nil {
+		return nil, err
+	}
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return nil, err
+	}
+	var pulls []*platforms.PullInfo
+	err = httpx.Paginate[*gitea.PullRequest](func(page int) ([]*gitea.PullRequest, error) {
+		items, _, err := client.ListRepoPullRequests(owner, repo, gitea.ListPullRequestsOptions{
+			ListOptions: gitea.ListOptions{
+				Page:     page,
+				PageSize: 20,
+			},
+			State: gitea.StateAll,
+			Sort:  "oldest",
+		})
+		return items, err
+	}, func(pr *gitea.PullRequest) {
+		pulls = append(pulls, toPullInfo(pr))
+	})
+	return pulls, err
+}
+
+func (p *Platform) ListPullFiles(ctx context.Context, fullName, number string) ([]*platforms.PullFileInfo, error) {
+	client, err := p.GetClient()
+	if err != nil {
+		return nil, err
+	}
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return nil, err
+	}
+	index, err := strconv.ParseInt(number, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	var files []*platforms.PullFileInfo
+	err = httpx.Paginate[*gitea.ChangedFile](func(page int) ([]*gitea.ChangedFile, error) {
+		items, _, err := client.ListPullRequestFiles(owner, repo, index, gitea.ListPullRequestFilesOptions{
+			ListOptions: gitea.ListOptions{
+				Page:     page,
+				PageSize: 50,
+			},
+		})
+		return items, err
+	}, func(file *gitea.ChangedFile) {
+		files = append(files, &platforms.PullFileInfo{
+			Filename:  file.Filename,
+			Status:    file.Status,
+			Additions: file.Additions,
+			Deletions: file.Deletions,
+		})
+	})
+	return files, err
+}
+
+func (p *Platform) ListPullComments(ctx context.Context, fullName, number string) ([]*platforms.CommentInfo, error) {
+	client, err := p.GetClient()
+	if err != nil {
+		return nil, err
+	}
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return nil, err
+	}
+	index, err := strconv.ParseInt(number, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	// 普通评论与 Issue 评论共用接口
+	comments, err := p.ListIssueComments(ctx, fullName, number)
+	if err != nil {
+		return nil, err
+	}
+	var reviews []*gitea.PullReview
+	err = httpx.Paginate[*gitea.PullReview](func(page int) ([]*gitea.PullReview, error) {
+		items, _, err := client.ListPullReviews(owner, repo, index, gitea.ListPullReviewsOptions{
+			ListOptions: gitea.ListOptions{
+				Page:     page,
+				PageSize: 20,
+			},
+		})
+		return items, err
+	}, func(review *gitea.PullReview) {
+		reviews = append(reviews, review)
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, review := range reviews {
+		items, _, err := client.ListPullReviewComments(owner, repo, index, review.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, comment := range items {
+			info := &platforms.CommentInfo{
+				ID:        comment.ID,
+				Body:      comment.Body,
+				CreatedAt: comment.Created,
+				URL:       comment.HTMLURL,
+				Path:      comment.Path,
+				Line:      int(comment.LineNum),
+				Review:    true,
+			}
+			if comment.Reviewer != nil {
+				info.Author = comment.Reviewer.UserName
+			}
+			comments = append(comments, info)
+		}
+	}
+	sort.SliceStable(comments, func(i, j int) bool {
+		return comments[i].CreatedAt.Before(comments[j].CreatedAt)
+	})
+	return comments, nil
+}
+
+func (p *Platform) CreatePull(ctx context.Context, fullName string, pull *platforms.PullInfo) (*platforms.PullInfo, error) {
+	client, err := p.GetClient()
+	if err != nil {
+		return nil, err
+	}
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return nil, err
+	}
+	created, _, err := client.CreatePullRequest(owner, repo, gitea.CreatePullRequestOption{
+		Head:  pull.Head,
+		Base:  pull.Base,
+		Title: pull.Title,
+		Body:  pull.Body,
+	})
+	if err != nil {
+		return nil, err
+	}
+	info := toPullInfo(created)
+	if pull.State != platforms.PullStateOpen {
+		state := gitea.StateClosed
+		if _, _, err := client.EditPullRequest(owner, repo, created.Index, gitea.EditPullRequestOption{State: &state}); err != nil {
+			return info, err
+		}
+		info.State = platforms.PullStateClosed
+	}
+	return info, nil
+}
+
+func (p *Platform) CreatePullComment(ctx context.Context, fullName, number, body string) error {
+	return p.CreateIssueComment(ctx, fullName, number, body)
+}
+
+func toPullInfo(pr *gitea.PullRequest) *platforms.PullInfo {
+	info := &platforms.PullInfo{
+		Number: strconv.FormatInt(pr.Index, 10),
+		Title:  pr.Title,
+		Body:   pr.Body,
+		State:  string(pr.State),
+		URL:    pr.HTMLURL,
+	}
+	if pr.Poster != nil {
+		info.Author = pr.Poster.UserName
+	}
+	if pr.Created != nil {
+		info.CreatedAt = *pr.Created
+	}
+	if pr.Head != nil {
+		info.Head = pr.Head.Ref
+		info.HeadSHA = pr.Head.Sha
+	}
+	if pr.Base != nil {
+		info.Base = pr.Base.Ref
+	}
+	if pr.HasMerged {
+		info.State = platforms.PullStateMerged
+		if pr.Merged != nil {
+			info.MergedAt = *pr.Merged
+		}
+	}
+	return info
+}
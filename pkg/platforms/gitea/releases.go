@@ -7,6 +7,7 @@ import (
 	"github.com/chihqiang/mpgrm/pkg/httpx"
 	"github.com/chihqiang/mpgrm/pkg/platforms"
 	"github.com/chihqiang/mpgrm/pkg/x"
+	"net/http"
 	"os"
 	"path"
 	"path/filepath"
@@ -48,8 +49,11 @@ func (p *Platform) GetTagReleaseInfo(ctx context.Context, fullName, tagName stri
 	if err != nil {
 		return nil, err
 	}
-	release, _, err := client.GetReleaseByTag(owner, repo, tagName)
+	release, resp, err := client.GetReleaseByTag(owner, repo, tagName)
 	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("%w: %w", platforms.ErrNotFound, err)
+		}
 		return nil, fmt.Errorf("failed to get release by tag %s: %w", tagName, err)
 	}
 	inf := &platforms.ReleaseInfo{
@@ -65,6 +69,7 @@ func (p *Platform) GetTagReleaseInfo(ctx context.Context, fullName, tagName stri
 			ID:   attachment.ID,
 			Name: attachment.Name,
 			URL:  attachment.DownloadURL,
+			Size: attachment.Size,
 		})
 	}
 	return inf, nil
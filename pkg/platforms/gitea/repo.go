@@ -3,63 +3,31 @@ package gitea
 import (
 	"code.gitea.io/sdk/gitea"
 	"context"
+	"fmt"
 	"github.com/chihqiang/mpgrm/pkg/httpx"
+	"github.com/chihqiang/mpgrm/pkg/logx"
 	"github.com/chihqiang/mpgrm/pkg/platforms"
 	"github.com/chihqiang/mpgrm/pkg/x"
+	"net/url"
 )
 
 func (p *Platform) ListOrgRepo(ctx context.Context, orgName string) ([]*platforms.RepoInfo, error) {
-	client, err := p.GetClient()
-	if err != nil {
-		return nil, err
-	}
-	var rInfos []*platforms.RepoInfo
-	err = httpx.Paginate[*gitea.Repository](func(page int) ([]*gitea.Repository, error) {
-		repos, _, err := client.ListOrgRepos(orgName, gitea.ListOrgReposOptions{
-			ListOptions: gitea.ListOptions{
-				Page:     page,
-				PageSize: 20,
-			},
-		})
-		return repos, err
-	}, func(repo *gitea.Repository) {
-		rInfos = append(rInfos, &platforms.RepoInfo{
-			ID:          repo.ID,
-			Name:        repo.Name,
-			FullName:    repo.FullName,
-			Description: repo.Description,
-			Homepage:    repo.Website,
-			IsPrivate:   repo.Private,
-			CloneURL:    repo.CloneURL,
-		})
-	})
-	return rInfos, err
+	return p.listRepos(ctx, fmt.Sprintf("orgs/%s/repos", url.PathEscape(orgName)))
 }
 
 func (p *Platform) ListUserRepo(ctx context.Context) ([]*platforms.RepoInfo, error) {
-	client, err := p.GetClient()
-	if err != nil {
-		return nil, err
-	}
+	return p.listRepos(ctx, fmt.Sprintf("users/%s/repos", url.PathEscape(p.Credential.Username)))
+}
+
+// listRepos 分页读取仓库列表，直接解码接口响应以获得 SDK 未包含的主题、语言和许可证
+func (p *Platform) listRepos(ctx context.Context, route string) ([]*platforms.RepoInfo, error) {
 	var rInfos []*platforms.RepoInfo
-	err = httpx.Paginate[*gitea.Repository](func(page int) ([]*gitea.Repository, error) {
-		repos, _, err := client.ListUserRepos(p.Credential.Username, gitea.ListReposOptions{
-			ListOptions: gitea.ListOptions{
-				Page:     page,
-				PageSize: 20,
-			},
-		})
+	err := httpx.Paginate[*repoResponse](func(page int) ([]*repoResponse, error) {
+		var repos []*repoResponse
+		err := p.getJSON(ctx, fmt.Sprintf("%s?page=%d&limit=%d", route, page, 20), &repos)
 		return repos, err
-	}, func(repo *gitea.Repository) {
-		rInfos = append(rInfos, &platforms.RepoInfo{
-			ID:          repo.ID,
-			Name:        repo.Name,
-			FullName:    repo.FullName,
-			Description: repo.Description,
-			Homepage:    repo.Website,
-			IsPrivate:   repo.Private,
-			CloneURL:    repo.CloneURL,
-		})
+	}, func(repo *repoResponse) {
+		rInfos = append(rInfos, toRepoInfo(repo))
 	})
 	return rInfos, err
 }
@@ -69,23 +37,32 @@ func (p *Platform) GetRepoDetail(ctx context.Context, fullName string) (*platfor
 	if err != nil {
 		return nil, err
 	}
-	client, err := p.GetClient()
-	if err != nil {
+	var repoResp repoResponse
+	if err := p.getJSON(ctx, fmt.Sprintf("repos/%s/%s", url.PathEscape(owner), url.PathEscape(repo)), &repoResp); err != nil {
 		return nil, err
 	}
-	repoResp, _, err := client.GetRepo(owner, repo)
+	ri := toRepoInfo(&repoResp)
+	// 旧版本 Gitea 的仓库接口不返回主题和语言，单独查询失败时保留为空，不影响仓库详情
+	client, err := p.GetClient()
 	if err != nil {
-		return nil, err
+		return ri, nil
+	}
+	if repoResp.Topics == nil {
+		topics, _, err := client.ListRepoTopics(owner, repo, gitea.ListRepoTopicsOptions{})
+		if err != nil {
+			logx.Warn("list topics of %s: %v", fullName, err)
+		}
+		ri.Topics = topics
 	}
-	return &platforms.RepoInfo{
-		ID:          repoResp.ID,
-		Name:        repoResp.Name,
-		FullName:    repoResp.FullName,
-		Description: repoResp.Description,
-		Homepage:    repoResp.Website,
-		IsPrivate:   repoResp.Private,
-		CloneURL:    repoResp.CloneURL,
-	}, nil
+	if ri.Language == "" {
+		// 取代码量最大的语言作为主要语言
+		languages, _, err := client.GetRepoLanguages(owner, repo)
+		if err != nil {
+			logx.Warn("get languages of %s: %v", fullName, err)
+		}
+		ri.Language = primaryLanguage(languages)
+	}
+	return ri, nil
 }
 
 func (p *Platform) CreateRepo(ctx context.Context, repoInfo *platforms.RepoInfo) error {
@@ -99,11 +76,81 @@ func (p *Platform) CreateRepo(ctx context.Context, repoInfo *platforms.RepoInfo)
 		Private:     repoInfo.IsPrivate,
 	}
 	orgName, _ := repoInfo.GetOrgName()
+	var created *gitea.Repository
 	if orgName == "" || orgName == p.Credential.Username {
-		_, _, err = client.CreateRepo(opt)
+		created, _, err = client.CreateRepo(opt)
 	} else {
-		_, _, err = client.CreateOrgRepo(orgName, opt)
+		created, _, err = client.CreateOrgRepo(orgName, opt)
+	}
+	if err != nil || len(repoInfo.Topics) == 0 {
+		return err
 	}
+	// 创建接口不支持主题，需在创建后单独设置
+	_, err = client.SetRepoTopics(created.Owner.UserName, created.Name, repoInfo.Topics)
+	return err
+}
+
+func (p *Platform) UpdateRepo(ctx context.Context, repoInfo *platforms.RepoInfo) error {
+	owner, repo, err := repoInfo.GetOwnerRepo()
+	if err != nil {
+		return err
+	}
+	client, err := p.GetClient()
+	if err != nil {
+		return err
+	}
+	opt := gitea.EditRepoOption{
+		Description: &repoInfo.Description,
+		Website:     &repoInfo.Homepage,
+		Private:     &repoInfo.IsPrivate,
+	}
+	if repoInfo.DefaultBranch != "" {
+		opt.DefaultBranch = &repoInfo.DefaultBranch
+	}
+	// 归档后的仓库无法修改主题和其它设置：取消归档随其它设置最先提交，归档最后单独提交
+	if !repoInfo.IsArchived {
+		opt.Archived = &repoInfo.IsArchived
+	}
+	if _, _, err = client.EditRepo(owner, repo, opt); err != nil {
+		return err
+	}
+	topics := repoInfo.Topics
+	if topics == nil {
+		topics = []string{}
+	}
+	if _, err = client.SetRepoTopics(owner, repo, topics); err != nil {
+		return err
+	}
+	if repoInfo.IsArchived {
+		_, _, err = client.EditRepo(owner, repo, gitea.EditRepoOption{Archived: &repoInfo.IsArchived})
+	}
+	return err
+}
+
+func (p *Platform) SetDefaultBranch(ctx context.Context, fullName, branch string) error {
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return err
+	}
+	client, err := p.GetClient()
+	if err != nil {
+		return err
+	}
+	_, _, err = client.EditRepo(owner, repo, gitea.EditRepoOption{DefaultBranch: &branch})
+	return err
+}
+
+func (p *Platform) EnableWiki(ctx context.Context, fullName string) error {
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return err
+	}
+	client, err := p.GetClient()
+	if err != nil {
+		return err
+	}
+	hasWiki := true
+	_, _, err = client.EditRepo(owner, repo, gitea.EditRepoOption{HasWiki: &hasWiki})
 	return err
 }
 
@@ -119,3 +166,49 @@ func (p *Platform) DeleteRepo(ctx context.Context, repoInfo *platforms.RepoInfo)
 	_, err = client.DeleteRepo(owner, repo)
 	return err
 }
+
+// repoResponse 为仓库接口的响应，SDK 的 Repository 不包含主题、语言和许可证
+type repoResponse struct {
+	gitea.Repository
+	Topics   []string `json:"topics"`
+	Language string   `json:"language"`
+	Licenses []string `json:"licenses"`
+}
+
+func toRepoInfo(repo *repoResponse) *platforms.RepoInfo {
+	info := &platforms.RepoInfo{
+		ID:            repo.ID,
+		Name:          repo.Name,
+		FullName:      repo.FullName,
+		Description:   repo.Description,
+		Homepage:      repo.Website,
+		IsPrivate:     repo.Private,
+		CloneURL:      repo.CloneURL,
+		DefaultBranch: repo.DefaultBranch,
+		IsArchived:    repo.Archived,
+		IsMirror:      repo.Mirror,
+		Topics:        repo.Topics,
+		Language:      repo.Language,
+		Stars:         repo.Stars,
+		Forks:         repo.Forks,
+		Size:          int64(repo.Size),
+	}
+	if len(repo.Licenses) > 0 {
+		info.License = repo.Licenses[0]
+	}
+	return info
+}
+
+// primaryLanguage 返回代码字节数最多的语言
+func primaryLanguage(languages map[string]int64) string {
+	var (
+		language string
+		size     int64
+	)
+	for name, bytes := range languages {
+		if bytes > size || (bytes == size && name < language) {
+			language, size = name, bytes
+		}
+	}
+	return language
+}
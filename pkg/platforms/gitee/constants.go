@@ -1,6 +1,9 @@
 package gitee
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type CreateReleaseResponse struct {
 	ID              int64  `json:"id"`
@@ -83,3 +86,159 @@ type ReleasesTagResponse struct {
 		Name               string `json:"name"`
 	} `json:"assets"`
 }
+
+// ProjectResponse 为仓库接口返回的仓库信息，Gitee 不返回仓库大小
+type ProjectResponse struct {
+	ID              int64          `json:"id"`
+	Name            string         `json:"name"`
+	Path            string         `json:"path"`
+	FullName        string         `json:"full_name"`
+	Description     string         `json:"description"`
+	Homepage        string         `json:"homepage"`
+	Private         bool           `json:"private"`
+	HtmlUrl         string         `json:"html_url"`
+	DefaultBranch   string         `json:"default_branch"`
+	License         string         `json:"license"`
+	Language        string         `json:"language"`
+	StargazersCount int            `json:"stargazers_count"`
+	ForksCount      int            `json:"forks_count"`
+	ProjectLabels   []ProjectLabel `json:"project_labels"`
+}
+
+// ProjectLabel 为仓库标签（主题）
+type ProjectLabel struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Ident string `json:"ident"`
+}
+
+// User 为 Gitee 接口返回的用户信息
+type User struct {
+	ID      int64  `json:"id"`
+	Login   string `json:"login"`
+	Name    string `json:"name"`
+	HtmlUrl string `json:"html_url"`
+}
+
+// IssueResponse 为 Gitee Issue 接口返回的 Issue 信息
+type IssueResponse struct {
+	ID        int64     `json:"id"`
+	Number    string    `json:"number"`
+	Title     string    `json:"title"`
+	Body      string    `json:"body"`
+	State     string    `json:"state"`
+	HtmlUrl   string    `json:"html_url"`
+	User      User      `json:"user"`
+	Assignee  *User     `json:"assignee"`
+	CreatedAt time.Time `json:"created_at"`
+	Labels    []struct {
+		ID    int64  `json:"id"`
+		Name  string `json:"name"`
+		Color string `json:"color"`
+	} `json:"labels"`
+}
+
+// CommentResponse 为 Gitee Issue 评论接口返回的评论信息
+type CommentResponse struct {
+	ID        int64     `json:"id"`
+	Body      string    `json:"body"`
+	User      User      `json:"user"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PullBranch 为 Gitee 合并请求接口返回的分支信息
+type PullBranch struct {
+	Label string `json:"label"`
+	Ref   string `json:"ref"`
+	Sha   string `json:"sha"`
+}
+
+// PullResponse 为 Gitee 合并请求接口返回的合并请求信息
+type PullResponse struct {
+	ID        int64      `json:"id"`
+	Number    int64      `json:"number"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	State     string     `json:"state"`
+	HtmlUrl   string     `json:"html_url"`
+	User      User       `json:"user"`
+	Head      PullBranch `json:"head"`
+	Base      PullBranch `json:"base"`
+	CreatedAt time.Time  `json:"created_at"`
+	MergedAt  *time.Time `json:"merged_at"`
+}
+
+// PullFileResponse 为 Gitee 合并请求变更文件接口返回的文件信息
+type PullFileResponse struct {
+	Filename  string      `json:"filename"`
+	Status    string      `json:"status"`
+	Additions json.Number `json:"additions"`
+	Deletions json.Number `json:"deletions"`
+}
+
+// PullCommentResponse 为 Gitee 合并请求评论接口返回的评论信息
+type PullCommentResponse struct {
+	ID        int64     `json:"id"`
+	Body      string    `json:"body"`
+	User      User      `json:"user"`
+	CreatedAt time.Time `json:"created_at"`
+	Path      string    `json:"path"`
+	Line      int       `json:"new_line"`
+}
+
+// LabelResponse 为 Gitee 标签接口返回的标签信息
+type LabelResponse struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// MilestoneResponse 为 Gitee 里程碑接口返回的里程碑信息
+type MilestoneResponse struct {
+	Number      int64  `json:"number"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	State       string `json:"state"`
+	DueOn       string `json:"due_on"`
+}
+
+// HookResponse 为 Gitee Webhook 接口返回的 Webhook 信息
+type HookResponse struct {
+	ID                  int64  `json:"id"`
+	URL                 string `json:"url"`
+	PushEvents          bool   `json:"push_events"`
+	TagPushEvents       bool   `json:"tag_push_events"`
+	IssuesEvents        bool   `json:"issues_events"`
+	NoteEvents          bool   `json:"note_events"`
+	MergeRequestsEvents bool   `json:"merge_requests_events"`
+}
+
+// BranchResponse 为 Gitee 分支列表接口返回的分支信息
+type BranchResponse struct {
+	Name      string `json:"name"`
+	Protected bool   `json:"protected"`
+}
+
+// CollaboratorResponse 为 Gitee 仓库协作者接口返回的用户信息
+type CollaboratorResponse struct {
+	Login       string `json:"login"`
+	Permissions struct {
+		Pull  bool `json:"pull"`
+		Push  bool `json:"push"`
+		Admin bool `json:"admin"`
+	} `json:"permissions"`
+}
+
+// DeployKeyResponse 为 Gitee 部署公钥接口返回的公钥信息
+type DeployKeyResponse struct {
+	ID    int64  `json:"id"`
+	Key   string `json:"key"`
+	Title string `json:"title"`
+}
+
+// OrgResponse 为 Gitee 组织接口返回的组织信息
+type OrgResponse struct {
+	Login       string `json:"login"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
@@ -0,0 +1,96 @@
+package gitee
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/chihqiang/mpgrm/pkg/httpx"
+	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"net/http"
+	"slices"
+)
+
+func (p *Platform) ListHooks(ctx context.Context, fullName string) ([]*platforms.HookInfo, error) {
+	apiURL := p.GetURLWithToken(fmt.Sprintf("repos/%s/hooks", fullName), map[string]string{
+		"per_page": "100",
+	})
+	var items []*HookResponse
+	if _, err := httpx.GetD(ctx, apiURL, &items); err != nil {
+		return nil, err
+	}
+	hooks := make([]*platforms.HookInfo, 0, len(items))
+	for _, item := range items {
+		// Gitee 的 Webhook 固定以 JSON 发送且没有启用开关
+		info := &platforms.HookInfo{
+			ID:          item.ID,
+			URL:         item.URL,
+			ContentType: platforms.HookContentTypeJSON,
+		}
+		for event, enabled := range map[string]bool{
+			platforms.HookEventPush:         item.PushEvents,
+			platforms.HookEventTagPush:      item.TagPushEvents,
+			platforms.HookEventIssues:       item.IssuesEvents,
+			platforms.HookEventIssueComment: item.NoteEvents,
+			platforms.HookEventPullRequest:  item.MergeRequestsEvents,
+		} {
+			if enabled {
+				info.Events = append(info.Events, event)
+			}
+		}
+		hooks = append(hooks, info)
+	}
+	return hooks, nil
+}
+
+func (p *Platform) CreateHook(ctx context.Context, fullName string, hook *platforms.HookInfo) error {
+	jsonData, _ := json.Marshal(hookForm(hook))
+	apiURL := p.GetURLWithToken(fmt.Sprintf("repos/%s/hooks", fullName), map[string]string{})
+	_, err := httpx.Post(ctx, apiURL, bytes.NewBuffer(jsonData), map[string]string{
+		"content-type": "application/json;charset=UTF-8",
+	})
+	return err
+}
+
+func (p *Platform) UpdateHook(ctx context.Context, fullName string, hook *platforms.HookInfo) error {
+	jsonData, _ := json.Marshal(hookForm(hook))
+	apiURL := p.GetURLWithToken(fmt.Sprintf("repos/%s/hooks/%d", fullName, hook.ID), map[string]string{})
+	_, err := httpx.Request(ctx, http.MethodPatch, apiURL, bytes.NewBuffer(jsonData), map[string]string{
+		"content-type": "application/json;charset=UTF-8",
+	})
+	return err
+}
+
+func (p *Platform) DeleteHook(ctx context.Context, fullName string, id int64) error {
+	apiURL := p.GetURLWithToken(fmt.Sprintf("repos/%s/hooks/%d", fullName, id), map[string]string{})
+	_, err := httpx.Request(ctx, http.MethodDelete, apiURL, nil, map[string]string{})
+	return err
+}
+
+// HookEvents Gitee 不支持 Release 事件
+func (p *Platform) HookEvents() []string {
+	return []string{
+		platforms.HookEventPush,
+		platforms.HookEventTagPush,
+		platforms.HookEventIssues,
+		platforms.HookEventIssueComment,
+		platforms.HookEventPullRequest,
+	}
+}
+
+func hookForm(hook *platforms.HookInfo) map[string]any {
+	form := map[string]any{
+		"url":                   hook.URL,
+		"push_events":           slices.Contains(hook.Events, platforms.HookEventPush),
+		"tag_push_events":       slices.Contains(hook.Events, platforms.HookEventTagPush),
+		"issues_events":         slices.Contains(hook.Events, platforms.HookEventIssues),
+		"note_events":           slices.Contains(hook.Events, platforms.HookEventIssueComment),
+		"merge_requests_events": slices.Contains(hook.Events, platforms.HookEventPullRequest),
+	}
+	// encryption_type 为 1 时使用签名密钥，Gitee 使用 password 字段保存密钥
+	if hook.Secret != "" {
+		form["password"] = hook.Secret
+		form["encryption_type"] = 1
+	}
+	return form
+}
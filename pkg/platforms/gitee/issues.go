@@ -0,0 +1,136 @@
+package gitee
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/chihqiang/mpgrm/pkg/httpx"
+	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"github.com/chihqiang/mpgrm/pkg/x"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+func (p *Platform) ListIssues(ctx context.Context, fullName string) ([]*platforms.IssueInfo, error) {
+	var issues []*platforms.IssueInfo
+	err := httpx.Paginate[*IssueResponse](func(page int) ([]*IssueResponse, error) {
+		apiURL := p.GetURLWithToken(fmt.Sprintf("repos/%s/issues", fullName), map[string]string{
+			"state":     "all",
+			"sort":      "created",
+			"direction": "asc",
+			"page":      strconv.Itoa(page),
+			"per_page":  "50",
+		})
+		var items []*IssueResponse
+		_, err := httpx.GetD(ctx, apiURL, &items)
+		return items, err
+	}, func(issue *IssueResponse) {
+		issues = append(issues, toIssueInfo(issue))
+	})
+	return issues, err
+}
+
+func (p *Platform) CreateIssue(ctx context.Context, fullName string, issue *platforms.IssueInfo) (*platforms.IssueInfo, error) {
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return nil, err
+	}
+	form := map[string]string{
+		"repo":   repo,
+		"title":  issue.Title,
+		"body":   issue.Body,
+		"labels": strings.Join(issue.Labels, ","),
+	}
+	// Gitee 的 Issue 只支持一个负责人
+	if len(issue.Assignees) > 0 {
+		form["assignee"] = issue.Assignees[0]
+	}
+	jsonData, _ := json.Marshal(form)
+	apiURL := p.GetURLWithToken(fmt.Sprintf("repos/%s/issues", owner), map[string]string{})
+	var result IssueResponse
+	if _, err := httpx.PostD(ctx, apiURL, bytes.NewBuffer(jsonData), &result, map[string]string{
+		"content-type": "application/json;charset=UTF-8",
+	}); err != nil {
+		return nil, err
+	}
+	info := toIssueInfo(&result)
+	if issue.State == platforms.IssueStateClosed {
+		if err := p.UpdateIssueState(ctx, fullName, info.Number, platforms.IssueStateClosed); err != nil {
+			return info, err
+		}
+		info.State = platforms.IssueStateClosed
+	}
+	return info, nil
+}
+
+func (p *Platform) UpdateIssueState(ctx context.Context, fullName, number, state string) error {
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return err
+	}
+	jsonData, _ := json.Marshal(map[string]string{
+		"repo":  repo,
+		"state": state,
+	})
+	apiURL := p.GetURLWithToken(fmt.Sprintf("repos/%s/issues/%s", owner, number), map[string]string{})
+	_, err = httpx.Request(ctx, http.MethodPatch, apiURL, bytes.NewBuffer(jsonData), map[string]string{
+		"content-type": "application/json;charset=UTF-8",
+	})
+	return err
+}
+
+func (p *Platform) ListIssueComments(ctx context.Context, fullName, number string) ([]*platforms.CommentInfo, error) {
+	var comments []*platforms.CommentInfo
+	err := httpx.Paginate[*CommentResponse](func(page int) ([]*CommentResponse, error) {
+		apiURL := p.GetURLWithToken(fmt.Sprintf("repos/%s/issues/%s/comments", fullName, number), map[string]string{
+			"order":    "asc",
+			"page":     strconv.Itoa(page),
+			"per_page": "50",
+		})
+		var items []*CommentResponse
+		_, err := httpx.GetD(ctx, apiURL, &items)
+		return items, err
+	}, func(comment *CommentResponse) {
+		comments = append(comments, &platforms.CommentInfo{
+			ID:        comment.ID,
+			Body:      comment.Body,
+			Author:    comment.User.Login,
+			CreatedAt: comment.CreatedAt,
+		})
+	})
+	return comments, err
+}
+
+func (p *Platform) CreateIssueComment(ctx context.Context, fullName, number, body string) error {
+	jsonData, _ := json.Marshal(map[string]string{"body": body})
+	apiURL := p.GetURLWithToken(fmt.Sprintf("repos/%s/issues/%s/comments", fullName, number), map[string]string{})
+	_, err := httpx.Post(ctx, apiURL, bytes.NewBuffer(jsonData), map[string]string{
+		"content-type": "application/json;charset=UTF-8",
+	})
+	return err
+}
+
+func toIssueInfo(issue *IssueResponse) *platforms.IssueInfo {
+	info := &platforms.IssueInfo{
+		Number:    issue.Number,
+		Title:     issue.Title,
+		Body:      issue.Body,
+		State:     platforms.IssueStateOpen,
+		Author:    issue.User.Login,
+		CreatedAt: issue.CreatedAt,
+		URL:       issue.HtmlUrl,
+	}
+	// Gitee 额外有 progressing（进行中）和 rejected（已拒绝）状态
+	if issue.State == "closed" || issue.State == "rejected" {
+		info.State = platforms.IssueStateClosed
+	}
+	for _, label := range issue.Labels {
+		info.Labels = append(info.Labels, label.Name)
+	}
+	if issue.Assignee != nil && issue.Assignee.Login != "" {
+		info.Assignees = append(info.Assignees, issue.Assignee.Login)
+	}
+	return info
+}
@@ -0,0 +1,54 @@
+package gitee
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/chihqiang/mpgrm/pkg/httpx"
+	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"net/http"
+	"strconv"
+)
+
+// ListDeployKeys Gitee 的部署公钥只能用于拉取
+func (p *Platform) ListDeployKeys(ctx context.Context, fullName string) ([]*platforms.DeployKeyInfo, error) {
+	var keys []*platforms.DeployKeyInfo
+	err := httpx.Paginate[*DeployKeyResponse](func(page int) ([]*DeployKeyResponse, error) {
+		apiURL := p.GetURLWithToken(fmt.Sprintf("repos/%s/keys", fullName), map[string]string{
+			"page":     strconv.Itoa(page),
+			"per_page": "100",
+		})
+		var items []*DeployKeyResponse
+		_, err := httpx.GetD(ctx, apiURL, &items)
+		return items, err
+	}, func(item *DeployKeyResponse) {
+		readOnly := true
+		keys = append(keys, &platforms.DeployKeyInfo{
+			ID:       item.ID,
+			Title:    item.Title,
+			Key:      item.Key,
+			ReadOnly: &readOnly,
+		})
+	})
+	return keys, err
+}
+
+// AddDeployKey Gitee 不支持可写的部署公钥，read_only 会被忽略
+func (p *Platform) AddDeployKey(ctx context.Context, fullName string, key *platforms.DeployKeyInfo) error {
+	jsonData, _ := json.Marshal(map[string]string{
+		"key":   key.Key,
+		"title": key.Title,
+	})
+	apiURL := p.GetURLWithToken(fmt.Sprintf("repos/%s/keys", fullName), map[string]string{})
+	_, err := httpx.Post(ctx, apiURL, bytes.NewBuffer(jsonData), map[string]string{
+		"content-type": "application/json;charset=UTF-8",
+	})
+	return err
+}
+
+func (p *Platform) RemoveDeployKey(ctx context.Context, fullName string, id int64) error {
+	apiURL := p.GetURLWithToken(fmt.Sprintf("repos/%s/keys/%d", fullName, id), map[string]string{})
+	_, err := httpx.Request(ctx, http.MethodDelete, apiURL, nil, map[string]string{})
+	return err
+}
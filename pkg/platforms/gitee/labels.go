@@ -0,0 +1,122 @@
+package gitee
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/chihqiang/mpgrm/pkg/httpx"
+	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+func (p *Platform) ListLabels(ctx context.Context, fullName string) ([]*platforms.LabelInfo, error) {
+	apiURL := p.GetURLWithToken(fmt.Sprintf("repos/%s/labels", fullName), map[string]string{})
+	var items []*LabelResponse
+	if _, err := httpx.GetD(ctx, apiURL, &items); err != nil {
+		return nil, err
+	}
+	labels := make([]*platforms.LabelInfo, 0, len(items))
+	for _, item := range items {
+		labels = append(labels, &platforms.LabelInfo{
+			ID:    item.ID,
+			Name:  item.Name,
+			Color: platforms.NormalizeColor(item.Color),
+		})
+	}
+	return labels, nil
+}
+
+func (p *Platform) CreateLabel(ctx context.Context, fullName string, label *platforms.LabelInfo) error {
+	jsonData, _ := json.Marshal(map[string]string{
+		"name":  label.Name,
+		"color": platforms.NormalizeColor(label.Color),
+	})
+	apiURL := p.GetURLWithToken(fmt.Sprintf("repos/%s/labels", fullName), map[string]string{})
+	_, err := httpx.Post(ctx, apiURL, bytes.NewBuffer(jsonData), map[string]string{
+		"content-type": "application/json;charset=UTF-8",
+	})
+	return err
+}
+
+func (p *Platform) UpdateLabel(ctx context.Context, fullName string, label *platforms.LabelInfo) error {
+	jsonData, _ := json.Marshal(map[string]string{
+		"name":  label.Name,
+		"color": platforms.NormalizeColor(label.Color),
+	})
+	apiURL := p.GetURLWithToken(fmt.Sprintf("repos/%s/labels/%s", fullName, url.PathEscape(label.Name)), map[string]string{})
+	_, err := httpx.Request(ctx, http.MethodPatch, apiURL, bytes.NewBuffer(jsonData), map[string]string{
+		"content-type": "application/json;charset=UTF-8",
+	})
+	return err
+}
+
+// LabelDescription Gitee 的标签没有描述字段
+func (p *Platform) LabelDescription() bool {
+	return false
+}
+
+func (p *Platform) ListMilestones(ctx context.Context, fullName string) ([]*platforms.MilestoneInfo, error) {
+	var milestones []*platforms.MilestoneInfo
+	err := httpx.Paginate[*MilestoneResponse](func(page int) ([]*MilestoneResponse, error) {
+		apiURL := p.GetURLWithToken(fmt.Sprintf("repos/%s/milestones", fullName), map[string]string{
+			"state":    "all",
+			"page":     strconv.Itoa(page),
+			"per_page": "50",
+		})
+		var items []*MilestoneResponse
+		_, err := httpx.GetD(ctx, apiURL, &items)
+		return items, err
+	}, func(milestone *MilestoneResponse) {
+		info := &platforms.MilestoneInfo{
+			Number:      strconv.FormatInt(milestone.Number, 10),
+			Title:       milestone.Title,
+			Description: milestone.Description,
+			State:       platforms.MilestoneStateOpen,
+		}
+		// Gitee 额外有 started（进行中）状态
+		if milestone.State == platforms.MilestoneStateClosed {
+			info.State = platforms.MilestoneStateClosed
+		}
+		if dueOn, err := time.Parse(time.DateOnly, milestone.DueOn); err == nil {
+			info.DueOn = dueOn
+		} else if dueOn, err := time.Parse(time.RFC3339, milestone.DueOn); err == nil {
+			info.DueOn = dueOn
+		}
+		milestones = append(milestones, info)
+	})
+	return milestones, err
+}
+
+func (p *Platform) CreateMilestone(ctx context.Context, fullName string, milestone *platforms.MilestoneInfo) error {
+	jsonData, _ := json.Marshal(milestoneForm(milestone))
+	apiURL := p.GetURLWithToken(fmt.Sprintf("repos/%s/milestones", fullName), map[string]string{})
+	_, err := httpx.Post(ctx, apiURL, bytes.NewBuffer(jsonData), map[string]string{
+		"content-type": "application/json;charset=UTF-8",
+	})
+	return err
+}
+
+func (p *Platform) UpdateMilestone(ctx context.Context, fullName string, milestone *platforms.MilestoneInfo) error {
+	jsonData, _ := json.Marshal(milestoneForm(milestone))
+	apiURL := p.GetURLWithToken(fmt.Sprintf("repos/%s/milestones/%s", fullName, milestone.Number), map[string]string{})
+	_, err := httpx.Request(ctx, http.MethodPatch, apiURL, bytes.NewBuffer(jsonData), map[string]string{
+		"content-type": "application/json;charset=UTF-8",
+	})
+	return err
+}
+
+func milestoneForm(milestone *platforms.MilestoneInfo) map[string]string {
+	form := map[string]string{
+		"title":       milestone.Title,
+		"description": milestone.Description,
+		"state":       milestone.State,
+	}
+	if !milestone.DueOn.IsZero() {
+		form["due_on"] = milestone.DueOn.UTC().Format(time.DateOnly)
+	}
+	return form
+}
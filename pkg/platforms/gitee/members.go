@@ -0,0 +1,56 @@
+package gitee
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/chihqiang/mpgrm/pkg/httpx"
+	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Gitee 使用 pull/push/admin 三级权限
+var permissions = map[string]string{
+	platforms.PermissionRead:  "pull",
+	platforms.PermissionWrite: "push",
+	platforms.PermissionAdmin: "admin",
+}
+
+func (p *Platform) ListCollaborators(ctx context.Context, fullName string) ([]*platforms.CollaboratorInfo, error) {
+	var collaborators []*platforms.CollaboratorInfo
+	err := httpx.Paginate[*CollaboratorResponse](func(page int) ([]*CollaboratorResponse, error) {
+		apiURL := p.GetURLWithToken(fmt.Sprintf("repos/%s/collaborators", fullName), map[string]string{
+			"page":     strconv.Itoa(page),
+			"per_page": "100",
+		})
+		var items []*CollaboratorResponse
+		_, err := httpx.GetD(ctx, apiURL, &items)
+		return items, err
+	}, func(item *CollaboratorResponse) {
+		permission := platforms.PermissionRead
+		if item.Permissions.Admin {
+			permission = platforms.PermissionAdmin
+		} else if item.Permissions.Push {
+			permission = platforms.PermissionWrite
+		}
+		collaborators = append(collaborators, &platforms.CollaboratorInfo{
+			Username:   item.Login,
+			Permission: permission,
+		})
+	})
+	return collaborators, err
+}
+
+func (p *Platform) AddCollaborator(ctx context.Context, fullName string, collaborator *platforms.CollaboratorInfo) error {
+	jsonData, _ := json.Marshal(map[string]string{
+		"permission": permissions[collaborator.Permission],
+	})
+	apiURL := p.GetURLWithToken(fmt.Sprintf("repos/%s/collaborators/%s", fullName, url.PathEscape(collaborator.Username)), map[string]string{})
+	_, err := httpx.Request(ctx, http.MethodPut, apiURL, bytes.NewBuffer(jsonData), map[string]string{
+		"content-type": "application/json;charset=UTF-8",
+	})
+	return err
+}
@@ -0,0 +1,49 @@
+package gitee
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/chihqiang/mpgrm/pkg/httpx"
+	"github.com/chihqiang/mpgrm/pkg/platforms"
+)
+
+func (p *Platform) GetOrg(ctx context.Context, name string) (*platforms.OrgInfo, error) {
+	apiURL := p.GetURLWithToken(fmt.Sprintf("orgs/%s", name), map[string]string{})
+	var org OrgResponse
+	if _, err := httpx.GetD(ctx, apiURL, &org); err != nil {
+		return nil, err
+	}
+	return &platforms.OrgInfo{
+		Name:        org.Login,
+		DisplayName: org.Name,
+		Description: org.Description,
+	}, nil
+}
+
+// CreateOrg Gitee 创建组织时不支持设置可见性和主页
+func (p *Platform) CreateOrg(ctx context.Context, org *platforms.OrgInfo) error {
+	displayName := org.DisplayName
+	if displayName == "" {
+		displayName = org.Name
+	}
+	jsonData, _ := json.Marshal(map[string]string{
+		"name":        displayName,
+		"org":         org.Name,
+		"description": org.Description,
+	})
+	apiURL := p.GetURLWithToken("users/organization", map[string]string{})
+	_, err := httpx.Post(ctx, apiURL, bytes.NewBuffer(jsonData), map[string]string{
+		"content-type": "application/json;charset=UTF-8",
+	})
+	return err
+}
+
+func (p *Platform) CurrentUser(ctx context.Context) (string, error) {
+	var user User
+	if _, err := httpx.GetD(ctx, p.GetURLWithToken("user", map[string]string{}), &user); err != nil {
+		return "", err
+	}
+	return user.Login, nil
+}
@@ -4,6 +4,7 @@ import (
 	"cnb.cool/zhiqiangwang/pkg/go-gitee/gitee"
 	"fmt"
 	"github.com/chihqiang/mpgrm/pkg/credential"
+	"github.com/chihqiang/mpgrm/pkg/httpx"
 	"net/url"
 )
 
@@ -31,7 +32,7 @@ func (p *Platform) WithCredential(credential *credential.Credential) error {
 }
 
 func (p *Platform) Client() *gitee.Client {
-	return gitee.NewClient(nil).WithToken(p.Credential.Token)
+	return gitee.NewClient(httpx.Client()).WithToken(p.Credential.Token)
 }
 
 // GetURLWithToken 构建带 Token 和额外 query 参数的完整 API URL
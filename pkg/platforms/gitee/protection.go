@@ -0,0 +1,66 @@
+package gitee
+
+import (
+	"context"
+	"fmt"
+	"github.com/chihqiang/mpgrm/pkg/httpx"
+	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"net/http"
+	"net/url"
+)
+
+// ListProtections Gitee 的保护分支只允许管理员推送，并禁止强制推送和删除
+func (p *Platform) ListProtections(ctx context.Context, fullName string) ([]*platforms.ProtectionRule, error) {
+	apiURL := p.GetURLWithToken(fmt.Sprintf("repos/%s/branches", fullName), map[string]string{})
+	var branches []*BranchResponse
+	if _, err := httpx.GetD(ctx, apiURL, &branches); err != nil {
+		return nil, err
+	}
+	var rules []*platforms.ProtectionRule
+	for _, branch := range branches {
+		if !branch.Protected {
+			continue
+		}
+		rules = append(rules, &platforms.ProtectionRule{
+			Branch:         branch.Name,
+			RestrictPush:   true,
+			BlockForcePush: true,
+			BlockDeletion:  true,
+		})
+	}
+	return rules, nil
+}
+
+// SetProtection Gitee 只能将分支设置为保护分支，规则中的评审、状态检查和推送白名单无法生效，以 UnenforcedError 返回
+func (p *Platform) SetProtection(ctx context.Context, fullName string, rule *platforms.ProtectionRule) error {
+	if rule.IsPattern() {
+		return fmt.Errorf("gitee branch protection does not support pattern %q", rule.Branch)
+	}
+	apiURL := p.GetURLWithToken(fmt.Sprintf("repos/%s/branches/%s/protection", fullName, url.PathEscape(rule.Branch)), map[string]string{})
+	_, err := httpx.Request(ctx, http.MethodPut, apiURL, nil, map[string]string{
+		"content-type": "application/json;charset=UTF-8",
+	})
+	if err != nil {
+		return err
+	}
+	var unenforced []string
+	if rule.RequiredReviews > 0 {
+		unenforced = append(unenforced, "required_reviews")
+	}
+	if rule.DismissStaleReviews {
+		unenforced = append(unenforced, "dismiss_stale_reviews")
+	}
+	if len(rule.StatusChecks) > 0 {
+		unenforced = append(unenforced, "status_checks")
+	}
+	if len(rule.PushUsers) > 0 {
+		unenforced = append(unenforced, "push_users")
+	}
+	if len(rule.PushTeams) > 0 {
+		unenforced = append(unenforced, "push_teams")
+	}
+	if rule.EnforceAdmins {
+		unenforced = append(unenforced, "enforce_admins")
+	}
+	return platforms.Unenforced(unenforced...)
+}
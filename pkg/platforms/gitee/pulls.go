@@ -0,0 +1,131 @@
+package gitee
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/chihqiang/mpgrm/pkg/httpx"
+	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"net/http"
+	"strconv"
+)
+
+func (p *Platform) ListPulls(ctx context.Context, fullName string) ([]*platforms.PullInfo, error) {
+	var pulls []*platforms.PullInfo
+	err := httpx.Paginate[*PullResponse](func(page int) ([]*PullResponse, error) {
+		apiURL := p.GetURLWithToken(fmt.Sprintf("repos/%s/pulls", fullName), map[string]string{
+			"state":     "all",
+			"sort":      "created",
+			"direction": "asc",
+			"page":      strconv.Itoa(page),
+			"per_page":  "50",
+		})
+		var items []*PullResponse
+		_, err := httpx.GetD(ctx, apiURL, &items)
+		return items, err
+	}, func(pr *PullResponse) {
+		pulls = append(pulls, toPullInfo(pr))
+	})
+	return pulls, err
+}
+
+func (p *Platform) ListPullFiles(ctx context.Context, fullName, number string) ([]*platforms.PullFileInfo, error) {
+	apiURL := p.GetURLWithToken(fmt.Sprintf("repos/%s/pulls/%s/files", fullName, number), map[string]string{})
+	var items []*PullFileResponse
+	if _, err := httpx.GetD(ctx, apiURL, &items); err != nil {
+		return nil, err
+	}
+	files := make([]*platforms.PullFileInfo, 0, len(items))
+	for _, item := range items {
+		additions, _ := item.Additions.Int64()
+		deletions, _ := item.Deletions.Int64()
+		files = append(files, &platforms.PullFileInfo{
+			Filename:  item.Filename,
+			Status:    item.Status,
+			Additions: int(additions),
+			Deletions: int(deletions),
+		})
+	}
+	return files, nil
+}
+
+func (p *Platform) ListPullComments(ctx context.Context, fullName, number string) ([]*platforms.CommentInfo, error) {
+	var comments []*platforms.CommentInfo
+	err := httpx.Paginate[*PullCommentResponse](func(page int) ([]*PullCommentResponse, error) {
+		apiURL := p.GetURLWithToken(fmt.Sprintf("repos/%s/pulls/%s/comments", fullName, number), map[string]string{
+			"page":     strconv.Itoa(page),
+			"per_page": "50",
+		})
+		var items []*PullCommentResponse
+		_, err := httpx.GetD(ctx, apiURL, &items)
+		return items, err
+	}, func(comment *PullCommentResponse) {
+		comments = append(comments, &platforms.CommentInfo{
+			ID:        comment.ID,
+			Body:      comment.Body,
+			Author:    comment.User.Login,
+			CreatedAt: comment.CreatedAt,
+			Path:      comment.Path,
+			Line:      comment.Line,
+		})
+	})
+	return comments, err
+}
+
+func (p *Platform) CreatePull(ctx context.Context, fullName string, pull *platforms.PullInfo) (*platforms.PullInfo, error) {
+	jsonData, _ := json.Marshal(map[string]string{
+		"title": pull.Title,
+		"head":  pull.Head,
+		"base":  pull.Base,
+		"body":  pull.Body,
+	})
+	apiURL := p.GetURLWithToken(fmt.Sprintf("repos/%s/pulls", fullName), map[string]string{})
+	var result PullResponse
+	if _, err := httpx.PostD(ctx, apiURL, bytes.NewBuffer(jsonData), &result, map[string]string{
+		"content-type": "application/json;charset=UTF-8",
+	}); err != nil {
+		return nil, err
+	}
+	info := toPullInfo(&result)
+	if pull.State != platforms.PullStateOpen {
+		jsonData, _ := json.Marshal(map[string]string{"state": platforms.PullStateClosed})
+		apiURL := p.GetURLWithToken(fmt.Sprintf("repos/%s/pulls/%s", fullName, info.Number), map[string]string{})
+		if _, err := httpx.Request(ctx, http.MethodPatch, apiURL, bytes.NewBuffer(jsonData), map[string]string{
+			"content-type": "application/json;charset=UTF-8",
+		}); err != nil {
+			return info, err
+		}
+		info.State = platforms.PullStateClosed
+	}
+	return info, nil
+}
+
+func (p *Platform) CreatePullComment(ctx context.Context, fullName, number, body string) error {
+	jsonData, _ := json.Marshal(map[string]string{"body": body})
+	apiURL := p.GetURLWithToken(fmt.Sprintf("repos/%s/pulls/%s/comments", fullName, number), map[string]string{})
+	_, err := httpx.Post(ctx, apiURL, bytes.NewBuffer(jsonData), map[string]string{
+		"content-type": "application/json;charset=UTF-8",
+	})
+	return err
+}
+
+func toPullInfo(pr *PullResponse) *platforms.PullInfo {
+	info := &platforms.PullInfo{
+		Number:    strconv.FormatInt(pr.Number, 10),
+		Title:     pr.Title,
+		Body:      pr.Body,
+		State:     pr.State,
+		Head:      pr.Head.Ref,
+		HeadSHA:   pr.Head.Sha,
+		Base:      pr.Base.Ref,
+		Author:    pr.User.Login,
+		CreatedAt: pr.CreatedAt,
+		URL:       pr.HtmlUrl,
+	}
+	if pr.MergedAt != nil {
+		info.State = platforms.PullStateMerged
+		info.MergedAt = *pr.MergedAt
+	}
+	return info
+}
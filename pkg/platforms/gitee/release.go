@@ -47,7 +47,7 @@ func (p *Platform) GetTagReleaseInfo(ctx context.Context, fullName, tagName stri
 		return nil, fmt.Errorf("request release by tag failed: %w", err)
 	}
 	if releasesTagResponse.TagName != tagName {
-		return nil, fmt.Errorf("release by tag %s not found", tagName)
+		return nil, fmt.Errorf("%w: release by tag %s", platforms.ErrNotFound, tagName)
 	}
 	info := &platforms.ReleaseInfo{
 		ID:          releasesTagResponse.ID,
@@ -68,6 +68,7 @@ func (p *Platform) GetTagReleaseInfo(ctx context.Context, fullName, tagName stri
 				ID:   release.ID,
 				Name: release.Name,
 				URL:  release.BrowserDownloadUrl,
+				Size: int64(release.Size),
 			})
 		}
 	}
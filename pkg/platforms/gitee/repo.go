@@ -1,77 +1,66 @@
 package gitee
 
 import (
+	"bytes"
 	"cnb.cool/zhiqiangwang/pkg/go-gitee/gitee"
-	"cnb.cool/zhiqiangwang/pkg/go-gitee/gitee/types/ibase"
 	"context"
+	"encoding/json"
+	"fmt"
 	"github.com/chihqiang/mpgrm/pkg/httpx"
+	"github.com/chihqiang/mpgrm/pkg/logx"
 	"github.com/chihqiang/mpgrm/pkg/platforms"
 	"github.com/chihqiang/mpgrm/pkg/x"
+	"net/http"
+	"net/url"
+	"strconv"
 )
 
 func (p *Platform) ListOrgRepo(ctx context.Context, orgName string) ([]*platforms.RepoInfo, error) {
-	var rInfos []*platforms.RepoInfo
-	err := httpx.Paginate[*ibase.Project](func(page int) ([]*ibase.Project, error) {
-		client := p.Client()
-		repos, _, err := client.Repositories.GetV5OrgsOrgRepos(ctx, orgName, &gitee.GetV5OrgsOrgReposOptions{
-			Page: page,
-		})
-		return repos, err
-	}, func(repo *ibase.Project) {
-		rInfos = append(rInfos, &platforms.RepoInfo{
-			ID:          int64(repo.Id),
-			Name:        repo.Name,
-			FullName:    repo.FullName,
-			Description: repo.Description,
-			Homepage:    repo.Homepage,
-			IsPrivate:   repo.Private,
-			CloneURL:    repo.HtmlUrl,
-		})
-	})
-	return rInfos, err
+	return p.listRepos(ctx, fmt.Sprintf("orgs/%s/repos", url.PathEscape(orgName)), map[string]string{"type": "all"})
 }
 
 func (p *Platform) ListUserRepo(ctx context.Context) ([]*platforms.RepoInfo, error) {
+	return p.listRepos(ctx, "user/repos", map[string]string{})
+}
+
+// listRepos 分页读取仓库列表，列表中已包含仓库标签（主题）、许可证和语言
+func (p *Platform) listRepos(ctx context.Context, route string, query map[string]string) ([]*platforms.RepoInfo, error) {
 	var rInfos []*platforms.RepoInfo
-	err := httpx.Paginate[*ibase.Project](func(page int) ([]*ibase.Project, error) {
-		client := p.Client()
-		repos, _, err := client.Repositories.GetV5UserRepos(ctx, &gitee.GetV5UserReposOptions{
-			Page: page,
-		})
+	err := httpx.Paginate[*ProjectResponse](func(page int) ([]*ProjectResponse, error) {
+		query["page"] = strconv.Itoa(page)
+		query["per_page"] = "20"
+		var repos []*ProjectResponse
+		_, err := httpx.GetD(ctx, p.GetURLWithToken(route, query), &repos)
 		return repos, err
-	}, func(repo *ibase.Project) {
-		rInfos = append(rInfos, &platforms.RepoInfo{
-			ID:          int64(repo.Id),
-			Name:        repo.Name,
-			FullName:    repo.FullName,
-			Description: repo.Description,
-			Homepage:    repo.Homepage,
-			IsPrivate:   repo.Private,
-			CloneURL:    repo.HtmlUrl,
-		})
+	}, func(repo *ProjectResponse) {
+		rInfos = append(rInfos, toRepoInfo(repo))
 	})
 	return rInfos, err
 }
 
 func (p *Platform) GetRepoDetail(ctx context.Context, fullName string) (*platforms.RepoInfo, error) {
-	client := p.Client()
 	owner, repo, err := x.RepoParseFullName(fullName)
 	if err != nil {
 		return nil, err
 	}
-	body, _, err := client.Repositories.GetV5ReposOwnerRepo(ctx, owner, repo, &gitee.GetV5ReposOwnerRepoOptions{})
-	if err != nil {
+	var body ProjectResponse
+	apiURL := p.GetURLWithToken(fmt.Sprintf("repos/%s/%s", url.PathEscape(owner), url.PathEscape(repo)), map[string]string{})
+	if _, err := httpx.GetD(ctx, apiURL, &body); err != nil {
 		return nil, err
 	}
-	return &platforms.RepoInfo{
-		ID:          int64(body.Id),
-		Name:        body.Name,
-		FullName:    body.FullName,
-		Description: body.Description,
-		Homepage:    body.Homepage,
-		IsPrivate:   body.Private,
-		CloneURL:    body.HtmlUrl,
-	}, nil
+	ri := toRepoInfo(&body)
+	if body.ProjectLabels == nil {
+		// 响应中没有仓库标签时单独查询，查询失败时保留为空，不影响仓库详情
+		var labels []ProjectLabel
+		apiURL := p.GetURLWithToken(fmt.Sprintf("repos/%s/%s/project_labels", owner, repo), map[string]string{})
+		if _, err := httpx.GetD(ctx, apiURL, &labels); err != nil {
+			logx.Warn("list project labels of %s: %v", fullName, err)
+		}
+		for _, label := range labels {
+			ri.Topics = append(ri.Topics, label.Name)
+		}
+	}
+	return ri, nil
 }
 
 func (p *Platform) CreateRepo(ctx context.Context, repoInfo *platforms.RepoInfo) error {
@@ -99,6 +88,84 @@ func (p *Platform) CreateRepo(ctx context.Context, repoInfo *platforms.RepoInfo)
 			CanComment:  true,
 		})
 	}
+	if err != nil || len(repoInfo.Topics) == 0 {
+		return err
+	}
+	// 创建接口不支持标签，需在创建后单独设置
+	owner := orgName
+	if owner == "" {
+		owner = p.Credential.Username
+	}
+	return p.setTopics(ctx, owner, repoInfo.Name, repoInfo.Topics)
+}
+
+func (p *Platform) UpdateRepo(ctx context.Context, repoInfo *platforms.RepoInfo) error {
+	owner, repo, err := repoInfo.GetOwnerRepo()
+	if err != nil {
+		return err
+	}
+	// Gitee 不支持通过 API 归档仓库，IsArchived 会被忽略
+	form := map[string]any{
+		"name":        repo,
+		"description": repoInfo.Description,
+		"homepage":    repoInfo.Homepage,
+		"private":     repoInfo.IsPrivate,
+	}
+	if repoInfo.DefaultBranch != "" {
+		form["default_branch"] = repoInfo.DefaultBranch
+	}
+	jsonData, _ := json.Marshal(form)
+	apiURL := p.GetURLWithToken(fmt.Sprintf("repos/%s/%s", owner, repo), map[string]string{})
+	if _, err := httpx.Request(ctx, http.MethodPatch, apiURL, bytes.NewBuffer(jsonData), map[string]string{
+		"content-type": "application/json;charset=UTF-8",
+	}); err != nil {
+		return err
+	}
+	return p.setTopics(ctx, owner, repo, repoInfo.Topics)
+}
+
+// setTopics 替换仓库的全部标签（主题）
+func (p *Platform) setTopics(ctx context.Context, owner, repo string, topics []string) error {
+	if topics == nil {
+		topics = []string{}
+	}
+	jsonData, _ := json.Marshal(topics)
+	apiURL := p.GetURLWithToken(fmt.Sprintf("repos/%s/%s/project_labels", owner, repo), map[string]string{})
+	_, err := httpx.Request(ctx, http.MethodPut, apiURL, bytes.NewBuffer(jsonData), map[string]string{
+		"content-type": "application/json;charset=UTF-8",
+	})
+	return err
+}
+
+func (p *Platform) SetDefaultBranch(ctx context.Context, fullName, branch string) error {
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return err
+	}
+	jsonData, _ := json.Marshal(map[string]string{
+		"name":           repo,
+		"default_branch": branch,
+	})
+	apiURL := p.GetURLWithToken(fmt.Sprintf("repos/%s/%s", owner, repo), map[string]string{})
+	_, err = httpx.Request(ctx, http.MethodPatch, apiURL, bytes.NewBuffer(jsonData), map[string]string{
+		"content-type": "application/json;charset=UTF-8",
+	})
+	return err
+}
+
+func (p *Platform) EnableWiki(ctx context.Context, fullName string) error {
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return err
+	}
+	jsonData, _ := json.Marshal(map[string]any{
+		"name":     repo,
+		"has_wiki": true,
+	})
+	apiURL := p.GetURLWithToken(fmt.Sprintf("repos/%s/%s", owner, repo), map[string]string{})
+	_, err = httpx.Request(ctx, http.MethodPatch, apiURL, bytes.NewBuffer(jsonData), map[string]string{
+		"content-type": "application/json;charset=UTF-8",
+	})
 	return err
 }
 
@@ -111,3 +178,24 @@ func (p *Platform) DeleteRepo(ctx context.Context, repoInfo *platforms.RepoInfo)
 	_, err = client.Repositories.DeleteV5ReposOwnerRepo(ctx, owner, repo, &gitee.DeleteV5ReposOwnerRepoOptions{})
 	return err
 }
+
+func toRepoInfo(repo *ProjectResponse) *platforms.RepoInfo {
+	info := &platforms.RepoInfo{
+		ID:            repo.ID,
+		Name:          repo.Name,
+		FullName:      repo.FullName,
+		Description:   repo.Description,
+		Homepage:      repo.Homepage,
+		IsPrivate:     repo.Private,
+		CloneURL:      repo.HtmlUrl,
+		DefaultBranch: repo.DefaultBranch,
+		License:       repo.License,
+		Language:      repo.Language,
+		Stars:         repo.StargazersCount,
+		Forks:         repo.ForksCount,
+	}
+	for _, label := range repo.ProjectLabels {
+		info.Topics = append(info.Topics, label.Name)
+	}
+	return info
+}
@@ -0,0 +1,114 @@
+package github
+
+import (
+	"context"
+	"github.com/chihqiang/mpgrm/pkg/httpx"
+	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"github.com/chihqiang/mpgrm/pkg/x"
+	"github.com/google/go-github/v73/github"
+	"slices"
+)
+
+// GitHub 的 push 事件同时包含分支和标签推送
+var hookEvents = map[string]string{
+	platforms.HookEventPush:         "push",
+	platforms.HookEventTagPush:      "push",
+	platforms.HookEventIssues:       "issues",
+	platforms.HookEventIssueComment: "issue_comment",
+	platforms.HookEventPullRequest:  "pull_request",
+	platforms.HookEventRelease:      "release",
+}
+
+func (p *Platform) ListHooks(ctx context.Context, fullName string) ([]*platforms.HookInfo, error) {
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return nil, err
+	}
+	client := p.GetClient(ctx)
+	var hooks []*platforms.HookInfo
+	err = httpx.Paginate[*github.Hook](func(page int) ([]*github.Hook, error) {
+		items, _, err := client.Repositories.ListHooks(ctx, owner, repo, &github.ListOptions{Page: page, PerPage: 100})
+		return items, err
+	}, func(hook *github.Hook) {
+		active := hook.GetActive()
+		info := &platforms.HookInfo{
+			ID:     hook.GetID(),
+			Active: &active,
+		}
+		if hook.Config != nil {
+			info.URL = hook.Config.GetURL()
+			info.ContentType = hook.Config.GetContentType()
+		}
+		for event, name := range hookEvents {
+			if slices.Contains(hook.Events, name) {
+				info.Events = append(info.Events, event)
+			}
+		}
+		hooks = append(hooks, info)
+	})
+	return hooks, err
+}
+
+func (p *Platform) CreateHook(ctx context.Context, fullName string, hook *platforms.HookInfo) error {
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return err
+	}
+	client := p.GetClient(ctx)
+	_, _, err = client.Repositories.CreateHook(ctx, owner, repo, toHook(hook))
+	return err
+}
+
+func (p *Platform) UpdateHook(ctx context.Context, fullName string, hook *platforms.HookInfo) error {
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return err
+	}
+	client := p.GetClient(ctx)
+	_, _, err = client.Repositories.EditHook(ctx, owner, repo, hook.ID, toHook(hook))
+	return err
+}
+
+func (p *Platform) DeleteHook(ctx context.Context, fullName string, id int64) error {
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return err
+	}
+	client := p.GetClient(ctx)
+	_, err = client.Repositories.DeleteHook(ctx, owner, repo, id)
+	return err
+}
+
+func (p *Platform) HookEvents() []string {
+	return []string{
+		platforms.HookEventPush,
+		platforms.HookEventTagPush,
+		platforms.HookEventIssues,
+		platforms.HookEventIssueComment,
+		platforms.HookEventPullRequest,
+		platforms.HookEventRelease,
+	}
+}
+
+func toHook(hook *platforms.HookInfo) *github.Hook {
+	contentType := hook.GetContentType()
+	active := hook.IsActive()
+	config := &github.HookConfig{
+		URL:         &hook.URL,
+		ContentType: &contentType,
+	}
+	if hook.Secret != "" {
+		config.Secret = &hook.Secret
+	}
+	events := []string{}
+	for _, event := range hook.Events {
+		if name, ok := hookEvents[event]; ok && !slices.Contains(events, name) {
+			events = append(events, name)
+		}
+	}
+	return &github.Hook{
+		Config: config,
+		Events: events,
+		Active: &active,
+	}
+}
@@ -0,0 +1,140 @@
+package github
+
+import (
+	"context"
+	"github.com/chihqiang/mpgrm/pkg/httpx"
+	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"github.com/chihqiang/mpgrm/pkg/x"
+	"github.com/google/go-github/v73/github"
+	"strconv"
+)
+
+func (p *Platform) ListIssues(ctx context.Context, fullName string) ([]*platforms.IssueInfo, error) {
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return nil, err
+	}
+	client := p.GetClient(ctx)
+	var issues []*platforms.IssueInfo
+	err = httpx.Paginate[*github.Issue](func(page int) ([]*github.Issue, error) {
+		items, _, err := client.Issues.ListByRepo(ctx, owner, repo, &github.IssueListByRepoOptions{
+			State:       "all",
+			Sort:        "created",
+			Direction:   "asc",
+			ListOptions: github.ListOptions{Page: page, PerPage: 50},
+		})
+		return items, err
+	}, func(issue *github.Issue) {
+		// GitHub 的 Issue 列表同时包含合并请求
+		if issue.IsPullRequest() {
+			return
+		}
+		issues = append(issues, toIssueInfo(issue))
+	})
+	return issues, err
+}
+
+func (p *Platform) CreateIssue(ctx context.Context, fullName string, issue *platforms.IssueInfo) (*platforms.IssueInfo, error) {
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return nil, err
+	}
+	client := p.GetClient(ctx)
+	req := &github.IssueRequest{
+		Title: &issue.Title,
+		Body:  &issue.Body,
+	}
+	if len(issue.Labels) > 0 {
+		req.Labels = &issue.Labels
+	}
+	if len(issue.Assignees) > 0 {
+		req.Assignees = &issue.Assignees
+	}
+	created, _, err := client.Issues.Create(ctx, owner, repo, req)
+	if err != nil {
+		return nil, err
+	}
+	info := toIssueInfo(created)
+	if issue.State == platforms.IssueStateClosed {
+		if err := p.UpdateIssueState(ctx, fullName, info.Number, platforms.IssueStateClosed); err != nil {
+			return info, err
+		}
+		info.State = platforms.IssueStateClosed
+	}
+	return info, nil
+}
+
+func (p *Platform) UpdateIssueState(ctx context.Context, fullName, number, state string) error {
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return err
+	}
+	n, err := strconv.Atoi(number)
+	if err != nil {
+		return err
+	}
+	client := p.GetClient(ctx)
+	_, _, err = client.Issues.Edit(ctx, owner, repo, n, &github.IssueRequest{State: &state})
+	return err
+}
+
+func (p *Platform) ListIssueComments(ctx context.Context, fullName, number string) ([]*platforms.CommentInfo, error) {
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(number)
+	if err != nil {
+		return nil, err
+	}
+	client := p.GetClient(ctx)
+	var comments []*platforms.CommentInfo
+	err = httpx.Paginate[*github.IssueComment](func(page int) ([]*github.IssueComment, error) {
+		items, _, err := client.Issues.ListComments(ctx, owner, repo, n, &github.IssueListCommentsOptions{
+			ListOptions: github.ListOptions{Page: page, PerPage: 50},
+		})
+		return items, err
+	}, func(comment *github.IssueComment) {
+		comments = append(comments, &platforms.CommentInfo{
+			ID:        comment.GetID(),
+			Body:      comment.GetBody(),
+			Author:    comment.GetUser().GetLogin(),
+			CreatedAt: comment.GetCreatedAt().Time,
+			URL:       comment.GetHTMLURL(),
+		})
+	})
+	return comments, err
+}
+
+func (p *Platform) CreateIssueComment(ctx context.Context, fullName, number, body string) error {
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return err
+	}
+	n, err := strconv.Atoi(number)
+	if err != nil {
+		return err
+	}
+	client := p.GetClient(ctx)
+	_, _, err = client.Issues.CreateComment(ctx, owner, repo, n, &github.IssueComment{Body: &body})
+	return err
+}
+
+func toIssueInfo(issue *github.Issue) *platforms.IssueInfo {
+	info := &platforms.IssueInfo{
+		Number:    strconv.Itoa(issue.GetNumber()),
+		Title:     issue.GetTitle(),
+		Body:      issue.GetBody(),
+		State:     issue.GetState(),
+		Author:    issue.GetUser().GetLogin(),
+		CreatedAt: issue.GetCreatedAt().Time,
+		URL:       issue.GetHTMLURL(),
+	}
+	for _, label := range issue.Labels {
+		info.Labels = append(info.Labels, label.GetName())
+	}
+	for _, assignee := range issue.Assignees {
+		info.Assignees = append(info.Assignees, assignee.GetLogin())
+	}
+	return info
+}
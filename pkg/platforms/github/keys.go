@@ -0,0 +1,56 @@
+package github
+
+import (
+	"context"
+	"github.com/chihqiang/mpgrm/pkg/httpx"
+	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"github.com/chihqiang/mpgrm/pkg/x"
+	"github.com/google/go-github/v73/github"
+)
+
+func (p *Platform) ListDeployKeys(ctx context.Context, fullName string) ([]*platforms.DeployKeyInfo, error) {
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return nil, err
+	}
+	client := p.GetClient(ctx)
+	var keys []*platforms.DeployKeyInfo
+	err = httpx.Paginate[*github.Key](func(page int) ([]*github.Key, error) {
+		items, _, err := client.Repositories.ListKeys(ctx, owner, repo, &github.ListOptions{Page: page, PerPage: 100})
+		return items, err
+	}, func(key *github.Key) {
+		readOnly := key.GetReadOnly()
+		keys = append(keys, &platforms.DeployKeyInfo{
+			ID:       key.GetID(),
+			Title:    key.GetTitle(),
+			Key:      key.GetKey(),
+			ReadOnly: &readOnly,
+		})
+	})
+	return keys, err
+}
+
+func (p *Platform) AddDeployKey(ctx context.Context, fullName string, key *platforms.DeployKeyInfo) error {
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return err
+	}
+	client := p.GetClient(ctx)
+	readOnly := key.IsReadOnly()
+	_, _, err = client.Repositories.CreateKey(ctx, owner, repo, &github.Key{
+		Title:    &key.Title,
+		Key:      &key.Key,
+		ReadOnly: &readOnly,
+	})
+	return err
+}
+
+func (p *Platform) RemoveDeployKey(ctx context.Context, fullName string, id int64) error {
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return err
+	}
+	client := p.GetClient(ctx)
+	_, err = client.Repositories.DeleteKey(ctx, owner, repo, id)
+	return err
+}
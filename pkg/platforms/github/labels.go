@@ -0,0 +1,125 @@
+package github
+
+import (
+	"context"
+	"github.com/chihqiang/mpgrm/pkg/httpx"
+	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"github.com/chihqiang/mpgrm/pkg/x"
+	"github.com/google/go-github/v73/github"
+	"strconv"
+)
+
+func (p *Platform) ListLabels(ctx context.Context, fullName string) ([]*platforms.LabelInfo, error) {
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return nil, err
+	}
+	client := p.GetClient(ctx)
+	var labels []*platforms.LabelInfo
+	err = httpx.Paginate[*github.Label](func(page int) ([]*github.Label, error) {
+		items, _, err := client.Issues.ListLabels(ctx, owner, repo, &github.ListOptions{Page: page, PerPage: 100})
+		return items, err
+	}, func(label *github.Label) {
+		labels = append(labels, &platforms.LabelInfo{
+			ID:          label.GetID(),
+			Name:        label.GetName(),
+			Color:       platforms.NormalizeColor(label.GetColor()),
+			Description: label.GetDescription(),
+		})
+	})
+	return labels, err
+}
+
+func (p *Platform) CreateLabel(ctx context.Context, fullName string, label *platforms.LabelInfo) error {
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return err
+	}
+	color := platforms.NormalizeColor(label.Color)
+	client := p.GetClient(ctx)
+	_, _, err = client.Issues.CreateLabel(ctx, owner, repo, &github.Label{
+		Name:        &label.Name,
+		Color:       &color,
+		Description: &label.Description,
+	})
+	return err
+}
+
+func (p *Platform) UpdateLabel(ctx context.Context, fullName string, label *platforms.LabelInfo) error {
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return err
+	}
+	color := platforms.NormalizeColor(label.Color)
+	client := p.GetClient(ctx)
+	_, _, err = client.Issues.EditLabel(ctx, owner, repo, label.Name, &github.Label{
+		Color:       &color,
+		Description: &label.Description,
+	})
+	return err
+}
+
+func (p *Platform) LabelDescription() bool {
+	return true
+}
+
+func (p *Platform) ListMilestones(ctx context.Context, fullName string) ([]*platforms.MilestoneInfo, error) {
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return nil, err
+	}
+	client := p.GetClient(ctx)
+	var milestones []*platforms.MilestoneInfo
+	err = httpx.Paginate[*github.Milestone](func(page int) ([]*github.Milestone, error) {
+		items, _, err := client.Issues.ListMilestones(ctx, owner, repo, &github.MilestoneListOptions{
+			State:       "all",
+			ListOptions: github.ListOptions{Page: page, PerPage: 100},
+		})
+		return items, err
+	}, func(milestone *github.Milestone) {
+		milestones = append(milestones, &platforms.MilestoneInfo{
+			Number:      strconv.Itoa(milestone.GetNumber()),
+			Title:       milestone.GetTitle(),
+			Description: milestone.GetDescription(),
+			State:       milestone.GetState(),
+			DueOn:       milestone.GetDueOn().Time,
+		})
+	})
+	return milestones, err
+}
+
+func (p *Platform) CreateMilestone(ctx context.Context, fullName string, milestone *platforms.MilestoneInfo) error {
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return err
+	}
+	client := p.GetClient(ctx)
+	_, _, err = client.Issues.CreateMilestone(ctx, owner, repo, toMilestone(milestone))
+	return err
+}
+
+func (p *Platform) UpdateMilestone(ctx context.Context, fullName string, milestone *platforms.MilestoneInfo) error {
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return err
+	}
+	number, err := strconv.Atoi(milestone.Number)
+	if err != nil {
+		return err
+	}
+	client := p.GetClient(ctx)
+	_, _, err = client.Issues.EditMilestone(ctx, owner, repo, number, toMilestone(milestone))
+	return err
+}
+
+func toMilestone(milestone *platforms.MilestoneInfo) *github.Milestone {
+	m := &github.Milestone{
+		Title:       &milestone.Title,
+		Description: &milestone.Description,
+		State:       &milestone.State,
+	}
+	if !milestone.DueOn.IsZero() {
+		m.DueOn = &github.Timestamp{Time: milestone.DueOn}
+	}
+	return m
+}
@@ -0,0 +1,154 @@
+package github
+
+import (
+	"context"
+	"github.com/chihqiang/mpgrm/pkg/httpx"
+	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"github.com/chihqiang/mpgrm/pkg/x"
+	"github.com/google/go-github/v73/github"
+)
+
+// GitHub 使用 pull/triage/push/maintain/admin 五级权限
+var permissions = map[string]string{
+	platforms.PermissionRead:  "pull",
+	platforms.PermissionWrite: "push",
+	platforms.PermissionAdmin: "admin",
+}
+
+// fromPermissions 将 GitHub 返回的权限集合转换为统一权限
+func fromPermissions(perms map[string]bool) string {
+	switch {
+	case perms["admin"]:
+		return platforms.PermissionAdmin
+	case perms["push"] || perms["maintain"]:
+		return platforms.PermissionWrite
+	default:
+		return platforms.PermissionRead
+	}
+}
+
+// fromPermission 将 GitHub 的权限名称转换为统一权限
+func fromPermission(permission string) string {
+	switch permission {
+	case "admin":
+		return platforms.PermissionAdmin
+	case "push", "maintain":
+		return platforms.PermissionWrite
+	default:
+		return platforms.PermissionRead
+	}
+}
+
+func (p *Platform) ListCollaborators(ctx context.Context, fullName string) ([]*platforms.CollaboratorInfo, error) {
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return nil, err
+	}
+	client := p.GetClient(ctx)
+	var collaborators []*platforms.CollaboratorInfo
+	err = httpx.Paginate[*github.User](func(page int) ([]*github.User, error) {
+		items, _, err := client.Repositories.ListCollaborators(ctx, owner, repo, &github.ListCollaboratorsOptions{
+			Affiliation: "direct",
+			ListOptions: github.ListOptions{Page: page, PerPage: 100},
+		})
+		return items, err
+	}, func(user *github.User) {
+		collaborators = append(collaborators, &platforms.CollaboratorInfo{
+			Username:   user.GetLogin(),
+			Permission: fromPermissions(user.Permissions),
+		})
+	})
+	return collaborators, err
+}
+
+// AddCollaborator GitHub 会向不在组织中的用户发送邀请，接受后才生效
+func (p *Platform) AddCollaborator(ctx context.Context, fullName string, collaborator *platforms.CollaboratorInfo) error {
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return err
+	}
+	client := p.GetClient(ctx)
+	_, _, err = client.Repositories.AddCollaborator(ctx, owner, repo, collaborator.Username, &github.RepositoryAddCollaboratorOptions{
+		Permission: permissions[collaborator.Permission],
+	})
+	return err
+}
+
+func (p *Platform) ListTeams(ctx context.Context, org string) ([]*platforms.TeamInfo, error) {
+	client := p.GetClient(ctx)
+	var teams []*platforms.TeamInfo
+	err := httpx.Paginate[*github.Team](func(page int) ([]*github.Team, error) {
+		items, _, err := client.Teams.ListTeams(ctx, org, &github.ListOptions{Page: page, PerPage: 100})
+		return items, err
+	}, func(team *github.Team) {
+		teams = append(teams, &platforms.TeamInfo{
+			ID:          team.GetID(),
+			Slug:        team.GetSlug(),
+			Name:        team.GetName(),
+			Description: team.GetDescription(),
+			Permission:  fromPermission(team.GetPermission()),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, team := range teams {
+		err = httpx.Paginate[*github.User](func(page int) ([]*github.User, error) {
+			items, _, err := client.Teams.ListTeamMembersBySlug(ctx, org, team.Slug, &github.TeamListTeamMembersOptions{
+				ListOptions: github.ListOptions{Page: page, PerPage: 100},
+			})
+			return items, err
+		}, func(user *github.User) {
+			team.Members = append(team.Members, user.GetLogin())
+		})
+		if err != nil {
+			return nil, err
+		}
+		err = httpx.Paginate[*github.Repository](func(page int) ([]*github.Repository, error) {
+			items, _, err := client.Teams.ListTeamReposBySlug(ctx, org, team.Slug, &github.ListOptions{Page: page, PerPage: 100})
+			return items, err
+		}, func(repo *github.Repository) {
+			team.Repos = append(team.Repos, repo.GetName())
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return teams, nil
+}
+
+func (p *Platform) CreateTeam(ctx context.Context, org string, team *platforms.TeamInfo) (*platforms.TeamInfo, error) {
+	client := p.GetClient(ctx)
+	privacy := "closed"
+	created, _, err := client.Teams.CreateTeam(ctx, org, github.NewTeam{
+		Name:        team.Name,
+		Description: &team.Description,
+		Privacy:     &privacy,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &platforms.TeamInfo{
+		ID:          created.GetID(),
+		Slug:        created.GetSlug(),
+		Name:        created.GetName(),
+		Description: created.GetDescription(),
+		Permission:  team.Permission,
+	}, nil
+}
+
+func (p *Platform) AddTeamMember(ctx context.Context, org string, team *platforms.TeamInfo, username string) error {
+	client := p.GetClient(ctx)
+	_, _, err := client.Teams.AddTeamMembershipBySlug(ctx, org, team.Slug, username, &github.TeamAddTeamMembershipOptions{
+		Role: "member",
+	})
+	return err
+}
+
+func (p *Platform) AddTeamRepo(ctx context.Context, org string, team *platforms.TeamInfo, repo string) error {
+	client := p.GetClient(ctx)
+	_, err := client.Teams.AddTeamRepoBySlug(ctx, org, team.Slug, org, repo, &github.TeamAddTeamRepoOptions{
+		Permission: permissions[team.Permission],
+	})
+	return err
+}
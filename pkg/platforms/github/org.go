@@ -0,0 +1,39 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"net/http"
+)
+
+func (p *Platform) GetOrg(ctx context.Context, name string) (*platforms.OrgInfo, error) {
+	client := p.GetClient(ctx)
+	org, resp, err := client.Organizations.Get(ctx, name)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("%w: %w", platforms.ErrNotFound, err)
+		}
+		return nil, err
+	}
+	return &platforms.OrgInfo{
+		Name:        org.GetLogin(),
+		DisplayName: org.GetName(),
+		Description: org.GetDescription(),
+		Homepage:    org.GetBlog(),
+	}, nil
+}
+
+// CreateOrg github.com 不支持通过 API 创建组织，需要在网页上手动创建
+func (p *Platform) CreateOrg(ctx context.Context, org *platforms.OrgInfo) error {
+	return fmt.Errorf("create organization %s on %s: %w", org.Name, HOST, errors.ErrUnsupported)
+}
+
+func (p *Platform) CurrentUser(ctx context.Context) (string, error) {
+	user, _, err := p.GetClient(ctx).Users.Get(ctx, "")
+	if err != nil {
+		return "", err
+	}
+	return user.GetLogin(), nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/chihqiang/mpgrm/pkg/credential"
+	"github.com/chihqiang/mpgrm/pkg/httpx"
 	"github.com/google/go-github/v73/github"
 	"golang.org/x/oauth2"
 )
@@ -21,7 +22,7 @@ type Platform struct {
 
 func (p *Platform) GetClient(ctx context.Context) *github.Client {
 	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: p.Credential.Token})
-	tc := oauth2.NewClient(ctx, ts)
+	tc := oauth2.NewClient(context.WithValue(ctx, oauth2.HTTPClient, httpx.Client()), ts)
 	return github.NewClient(tc)
 }
 
@@ -0,0 +1,106 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"github.com/chihqiang/mpgrm/pkg/httpx"
+	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"github.com/chihqiang/mpgrm/pkg/x"
+	"github.com/google/go-github/v73/github"
+)
+
+func (p *Platform) ListProtections(ctx context.Context, fullName string) ([]*platforms.ProtectionRule, error) {
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return nil, err
+	}
+	client := p.GetClient(ctx)
+	protected := true
+	var branches []string
+	err = httpx.Paginate[*github.Branch](func(page int) ([]*github.Branch, error) {
+		items, _, err := client.Repositories.ListBranches(ctx, owner, repo, &github.BranchListOptions{
+			Protected:   &protected,
+			ListOptions: github.ListOptions{Page: page, PerPage: 100},
+		})
+		return items, err
+	}, func(branch *github.Branch) {
+		branches = append(branches, branch.GetName())
+	})
+	if err != nil {
+		return nil, err
+	}
+	rules := make([]*platforms.ProtectionRule, 0, len(branches))
+	for _, branch := range branches {
+		protection, _, err := client.Repositories.GetBranchProtection(ctx, owner, repo, branch)
+		if err != nil {
+			return nil, fmt.Errorf("get protection of %s: %w", branch, err)
+		}
+		rules = append(rules, toProtectionRule(branch, protection))
+	}
+	return rules, nil
+}
+
+func (p *Platform) SetProtection(ctx context.Context, fullName string, rule *platforms.ProtectionRule) error {
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return err
+	}
+	// 通配符规则只能通过规则集（rulesets）配置
+	if rule.IsPattern() {
+		return fmt.Errorf("GitHub branch protection does not support pattern %q", rule.Branch)
+	}
+	allowForcePushes := !rule.BlockForcePush
+	allowDeletions := !rule.BlockDeletion
+	req := &github.ProtectionRequest{
+		EnforceAdmins:    rule.EnforceAdmins,
+		AllowForcePushes: &allowForcePushes,
+		AllowDeletions:   &allowDeletions,
+	}
+	if len(rule.StatusChecks) > 0 {
+		contexts := rule.StatusChecks
+		req.RequiredStatusChecks = &github.RequiredStatusChecks{Strict: true, Contexts: &contexts}
+	}
+	if rule.RequiredReviews > 0 {
+		req.RequiredPullRequestReviews = &github.PullRequestReviewsEnforcementRequest{
+			DismissStaleReviews:          rule.DismissStaleReviews,
+			RequiredApprovingReviewCount: rule.RequiredReviews,
+		}
+	}
+	// 推送限制仅对组织仓库生效
+	if rule.RestrictPush {
+		req.Restrictions = &github.BranchRestrictionsRequest{
+			Users: append([]string{}, rule.PushUsers...),
+			Teams: append([]string{}, rule.PushTeams...),
+			Apps:  []string{},
+		}
+	}
+	client := p.GetClient(ctx)
+	_, _, err = client.Repositories.UpdateBranchProtection(ctx, owner, repo, rule.Branch, req)
+	return err
+}
+
+func toProtectionRule(branch string, protection *github.Protection) *platforms.ProtectionRule {
+	rule := &platforms.ProtectionRule{
+		Branch:         branch,
+		BlockForcePush: protection.AllowForcePushes == nil || !protection.AllowForcePushes.Enabled,
+		BlockDeletion:  protection.AllowDeletions == nil || !protection.AllowDeletions.Enabled,
+		EnforceAdmins:  protection.EnforceAdmins != nil && protection.EnforceAdmins.Enabled,
+	}
+	if checks := protection.RequiredStatusChecks; checks != nil && checks.Contexts != nil {
+		rule.StatusChecks = *checks.Contexts
+	}
+	if reviews := protection.RequiredPullRequestReviews; reviews != nil {
+		rule.RequiredReviews = reviews.RequiredApprovingReviewCount
+		rule.DismissStaleReviews = reviews.DismissStaleReviews
+	}
+	if restrictions := protection.Restrictions; restrictions != nil {
+		rule.RestrictPush = true
+		for _, user := range restrictions.Users {
+			rule.PushUsers = append(rule.PushUsers, user.GetLogin())
+		}
+		for _, team := range restrictions.Teams {
+			rule.PushTeams = append(rule.PushTeams, team.GetSlug())
+		}
+	}
+	return rule
+}
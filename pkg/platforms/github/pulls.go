@@ -0,0 +1,148 @@
+package github
+
+import (
+	"context"
+	"github.com/chihqiang/mpgrm/pkg/httpx"
+	"github.com/chihqiang/mpgrm/pkg/platforms"
+	"github.com/chihqiang/mpgrm/pkg/x"
+	"github.com/google/go-github/v73/github"
+	"sort"
+	"strconv"
+)
+
+func (p *Platform) ListPulls(ctx context.Context, fullName string) ([]*platforms.PullInfo, error) {
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return nil, err
+	}
+	client := p.GetClient(ctx)
+	var pulls []*platforms.PullInfo
+	err = httpx.Paginate[*github.PullRequest](func(page int) ([]*github.PullRequest, error) {
+		items, _, err := client.PullRequests.List(ctx, owner, repo, &github.PullRequestListOptions{
+			State:       "all",
+			Sort:        "created",
+			Direction:   "asc",
+			ListOptions: github.ListOptions{Page: page, PerPage: 50},
+		})
+		return items, err
+	}, func(pr *github.PullRequest) {
+		pulls = append(pulls, toPullInfo(pr))
+	})
+	return pulls, err
+}
+
+func (p *Platform) ListPullFiles(ctx context.Context, fullName, number string) ([]*platforms.PullFileInfo, error) {
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(number)
+	if err != nil {
+		return nil, err
+	}
+	client := p.GetClient(ctx)
+	var files []*platforms.PullFileInfo
+	err = httpx.Paginate[*github.CommitFile](func(page int) ([]*github.CommitFile, error) {
+		items, _, err := client.PullRequests.ListFiles(ctx, owner, repo, n, &github.ListOptions{Page: page, PerPage: 100})
+		return items, err
+	}, func(file *github.CommitFile) {
+		files = append(files, &platforms.PullFileInfo{
+			Filename:  file.GetFilename(),
+			Status:    file.GetStatus(),
+			Additions: file.GetAdditions(),
+			Deletions: file.GetDeletions(),
+		})
+	})
+	return files, err
+}
+
+func (p *Platform) ListPullComments(ctx context.Context, fullName, number string) ([]*platforms.CommentInfo, error) {
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(number)
+	if err != nil {
+		return nil, err
+	}
+	// 普通评论与 Issue 评论共用接口
+	comments, err := p.ListIssueComments(ctx, fullName, number)
+	if err != nil {
+		return nil, err
+	}
+	client := p.GetClient(ctx)
+	err = httpx.Paginate[*github.PullRequestComment](func(page int) ([]*github.PullRequestComment, error) {
+		items, _, err := client.PullRequests.ListComments(ctx, owner, repo, n, &github.PullRequestListCommentsOptions{
+			Sort:        "created",
+			Direction:   "asc",
+			ListOptions: github.ListOptions{Page: page, PerPage: 50},
+		})
+		return items, err
+	}, func(comment *github.PullRequestComment) {
+		comments = append(comments, &platforms.CommentInfo{
+			ID:        comment.GetID(),
+			Body:      comment.GetBody(),
+			Author:    comment.GetUser().GetLogin(),
+			CreatedAt: comment.GetCreatedAt().Time,
+			URL:       comment.GetHTMLURL(),
+			Path:      comment.GetPath(),
+			Line:      comment.GetLine(),
+			Review:    true,
+		})
+	})
+	sort.SliceStable(comments, func(i, j int) bool {
+		return comments[i].CreatedAt.Before(comments[j].CreatedAt)
+	})
+	return comments, err
+}
+
+func (p *Platform) CreatePull(ctx context.Context, fullName string, pull *platforms.PullInfo) (*platforms.PullInfo, error) {
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return nil, err
+	}
+	client := p.GetClient(ctx)
+	created, _, err := client.PullRequests.Create(ctx, owner, repo, &github.NewPullRequest{
+		Title: &pull.Title,
+		Head:  &pull.Head,
+		Base:  &pull.Base,
+		Body:  &pull.Body,
+	})
+	if err != nil {
+		return nil, err
+	}
+	info := toPullInfo(created)
+	if pull.State != platforms.PullStateOpen {
+		state := platforms.PullStateClosed
+		if _, _, err := client.PullRequests.Edit(ctx, owner, repo, created.GetNumber(), &github.PullRequest{State: &state}); err != nil {
+			return info, err
+		}
+		info.State = platforms.PullStateClosed
+	}
+	return info, nil
+}
+
+func (p *Platform) CreatePullComment(ctx context.Context, fullName, number, body string) error {
+	return p.CreateIssueComment(ctx, fullName, number, body)
+}
+
+func toPullInfo(pr *github.PullRequest) *platforms.PullInfo {
+	info := &platforms.PullInfo{
+		Number:    strconv.Itoa(pr.GetNumber()),
+		Title:     pr.GetTitle(),
+		Body:      pr.GetBody(),
+		State:     pr.GetState(),
+		Head:      pr.GetHead().GetRef(),
+		HeadSHA:   pr.GetHead().GetSHA(),
+		Base:      pr.GetBase().GetRef(),
+		Author:    pr.GetUser().GetLogin(),
+		CreatedAt: pr.GetCreatedAt().Time,
+		URL:       pr.GetHTMLURL(),
+	}
+	// 列表接口不返回 merged 字段，以合并时间判断
+	if pr.MergedAt != nil {
+		info.State = platforms.PullStateMerged
+		info.MergedAt = pr.GetMergedAt().Time
+	}
+	return info
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/chihqiang/mpgrm/pkg/platforms"
 	"github.com/chihqiang/mpgrm/pkg/x"
 	"github.com/google/go-github/v73/github"
+	"net/http"
 	"os"
 	"path"
 	"path/filepath"
@@ -41,8 +42,11 @@ func (p *Platform) GetTagReleaseInfo(ctx context.Context, fullName, tagName stri
 		return nil, err
 	}
 	client := p.GetClient(ctx)
-	tag, _, err := client.Repositories.GetReleaseByTag(ctx, owner, repo, tagName)
+	tag, resp, err := client.Repositories.GetReleaseByTag(ctx, owner, repo, tagName)
 	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("%w: %w", platforms.ErrNotFound, err)
+		}
 		return nil, err
 	}
 	tI := &platforms.ReleaseInfo{
@@ -57,6 +61,7 @@ func (p *Platform) GetTagReleaseInfo(ctx context.Context, fullName, tagName stri
 			ID:   asset.GetID(),
 			Name: asset.GetName(),
 			URL:  asset.GetBrowserDownloadURL(),
+			Size: int64(asset.GetSize()),
 		})
 	}
 	return tI, nil
@@ -2,10 +2,12 @@ package github
 
 import (
 	"context"
+	"fmt"
 	"github.com/chihqiang/mpgrm/pkg/httpx"
 	"github.com/chihqiang/mpgrm/pkg/platforms"
 	"github.com/chihqiang/mpgrm/pkg/x"
 	"github.com/google/go-github/v73/github"
+	"net/http"
 )
 
 func (p *Platform) ListOrgRepo(ctx context.Context, orgName string) ([]*platforms.RepoInfo, error) {
@@ -19,15 +21,7 @@ func (p *Platform) ListOrgRepo(ctx context.Context, orgName string) ([]*platform
 		})
 		return repos, err
 	}, func(repo *github.Repository) {
-		rInfos = append(rInfos, &platforms.RepoInfo{
-			ID:          repo.GetID(),
-			Name:        repo.GetName(),
-			FullName:    repo.GetFullName(),
-			Description: repo.GetDescription(),
-			Homepage:    repo.GetHomepage(),
-			IsPrivate:   repo.GetPrivate(),
-			CloneURL:    repo.GetCloneURL(),
-		})
+		rInfos = append(rInfos, toRepoInfo(repo))
 	})
 	return rInfos, err
 }
@@ -41,15 +35,7 @@ func (p *Platform) ListUserRepo(ctx context.Context) ([]*platforms.RepoInfo, err
 		})
 		return repos, err
 	}, func(repo *github.Repository) {
-		rInfos = append(rInfos, &platforms.RepoInfo{
-			ID:          repo.GetID(),
-			Name:        repo.GetName(),
-			FullName:    repo.GetFullName(),
-			Description: repo.GetDescription(),
-			Homepage:    repo.GetHomepage(),
-			IsPrivate:   repo.GetPrivate(),
-			CloneURL:    repo.GetCloneURL(),
-		})
+		rInfos = append(rInfos, toRepoInfo(repo))
 	})
 	return rInfos, err
 }
@@ -60,20 +46,14 @@ func (p *Platform) GetRepoDetail(ctx context.Context, fullName string) (*platfor
 	if err != nil {
 		return nil, err
 	}
-	repoG, _, err := client.Repositories.Get(context.Background(), owner, repo)
+	repoG, resp, err := client.Repositories.Get(context.Background(), owner, repo)
 	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("%w: %w", platforms.ErrNotFound, err)
+		}
 		return nil, err
 	}
-	ri := &platforms.RepoInfo{
-		ID:          repoG.GetID(),
-		Name:        repoG.GetName(),
-		FullName:    repoG.GetFullName(),
-		Description: repoG.GetDescription(),
-		Homepage:    repoG.GetHomepage(),
-		IsPrivate:   repoG.GetPrivate(),
-		CloneURL:    repoG.GetCloneURL(),
-	}
-	return ri, nil
+	return toRepoInfo(repoG), nil
 }
 
 func (p *Platform) CreateRepo(ctx context.Context, repoInfo *platforms.RepoInfo) error {
@@ -88,6 +68,66 @@ func (p *Platform) CreateRepo(ctx context.Context, repoInfo *platforms.RepoInfo)
 		Description: &repoInfo.Description,
 		Homepage:    &repoInfo.Homepage,
 	})
+	if err != nil || len(repoInfo.Topics) == 0 {
+		return err
+	}
+	// 创建接口不支持主题，需在创建后单独设置
+	_, _, err = client.Repositories.ReplaceAllTopics(ctx, owner, repoInfo.Name, repoInfo.Topics)
+	return err
+}
+
+func (p *Platform) UpdateRepo(ctx context.Context, repoInfo *platforms.RepoInfo) error {
+	owner, repo, err := repoInfo.GetOwnerRepo()
+	if err != nil {
+		return err
+	}
+	client := p.GetClient(ctx)
+	edit := &github.Repository{
+		Description: &repoInfo.Description,
+		Homepage:    &repoInfo.Homepage,
+		Private:     &repoInfo.IsPrivate,
+	}
+	if repoInfo.DefaultBranch != "" {
+		edit.DefaultBranch = &repoInfo.DefaultBranch
+	}
+	// 归档后的仓库无法修改主题和其它设置：取消归档随其它设置最先提交，归档最后单独提交
+	if !repoInfo.IsArchived {
+		edit.Archived = &repoInfo.IsArchived
+	}
+	if _, _, err = client.Repositories.Edit(ctx, owner, repo, edit); err != nil {
+		return err
+	}
+	topics := repoInfo.Topics
+	if topics == nil {
+		topics = []string{}
+	}
+	if _, _, err = client.Repositories.ReplaceAllTopics(ctx, owner, repo, topics); err != nil {
+		return err
+	}
+	if repoInfo.IsArchived {
+		_, _, err = client.Repositories.Edit(ctx, owner, repo, &github.Repository{Archived: &repoInfo.IsArchived})
+	}
+	return err
+}
+
+func (p *Platform) SetDefaultBranch(ctx context.Context, fullName, branch string) error {
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return err
+	}
+	client := p.GetClient(ctx)
+	_, _, err = client.Repositories.Edit(ctx, owner, repo, &github.Repository{DefaultBranch: &branch})
+	return err
+}
+
+func (p *Platform) EnableWiki(ctx context.Context, fullName string) error {
+	owner, repo, err := x.RepoParseFullName(fullName)
+	if err != nil {
+		return err
+	}
+	hasWiki := true
+	client := p.GetClient(ctx)
+	_, _, err = client.Repositories.Edit(ctx, owner, repo, &github.Repository{HasWiki: &hasWiki})
 	return err
 }
 
@@ -100,3 +140,23 @@ func (p *Platform) DeleteRepo(ctx context.Context, repoInfo *platforms.RepoInfo)
 	_, err = client.Repositories.Delete(context.Background(), owner, repo)
 	return err
 }
+
+func toRepoInfo(repo *github.Repository) *platforms.RepoInfo {
+	return &platforms.RepoInfo{
+		ID:            repo.GetID(),
+		Name:          repo.GetName(),
+		FullName:      repo.GetFullName(),
+		Description:   repo.GetDescription(),
+		Homepage:      repo.GetHomepage(),
+		IsPrivate:     repo.GetPrivate(),
+		CloneURL:      repo.GetCloneURL(),
+		DefaultBranch: repo.GetDefaultBranch(),
+		IsArchived:    repo.GetArchived(),
+		Topics:        repo.Topics,
+		License:       repo.GetLicense().GetSPDXID(),
+		Language:      repo.GetLanguage(),
+		Stars:         repo.GetStargazersCount(),
+		Forks:         repo.GetForksCount(),
+		Size:          int64(repo.GetSize()),
+	}
+}
@@ -0,0 +1,99 @@
+package platforms
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// 统一的 Webhook 事件名称，各平台在实现中与自身事件互相转换
+const (
+	HookEventPush         = "push"          // 分支推送
+	HookEventTagPush      = "tag_push"      // 标签推送
+	HookEventIssues       = "issues"        // Issue 变更
+	HookEventIssueComment = "issue_comment" // Issue/合并请求评论
+	HookEventPullRequest  = "pull_request"  // 合并请求变更
+	HookEventRelease      = "release"       // Release 发布
+)
+
+const (
+	HookContentTypeJSON = "json" // application/json
+	HookContentTypeForm = "form" // application/x-www-form-urlencoded
+)
+
+// HookInfo 表示仓库的 Webhook 配置
+type HookInfo struct {
+	ID          int64    `json:"-"`                      // Webhook 在平台中的 ID，仅用于更新和删除
+	URL         string   `json:"url"`                    // 接收地址，平台间按地址匹配
+	ContentType string   `json:"content_type,omitempty"` // 内容类型，HookContentTypeJSON 或 HookContentTypeForm，默认为 json
+	Events      []string `json:"events"`                 // 统一的事件名称列表
+	Active      *bool    `json:"active,omitempty"`       // 是否启用，默认为启用
+	Secret      string   `json:"-"`                      // 签名密钥，平台不会返回已有密钥，只能来自环境变量
+	SecretEnv   string   `json:"secret_env,omitempty"`   // 模板文件中保存密钥的环境变量名
+}
+
+// IsActive 返回 Webhook 是否启用，未设置时视为启用
+func (hi *HookInfo) IsActive() bool {
+	return hi.Active == nil || *hi.Active
+}
+
+// GetContentType 返回内容类型，未设置时为 json
+func (hi *HookInfo) GetContentType() string {
+	if hi.ContentType == "" {
+		return HookContentTypeJSON
+	}
+	return hi.ContentType
+}
+
+// SupportedEvents 返回 events 中平台支持的事件，去重并排序
+func SupportedEvents(events, supported []string) []string {
+	var result []string
+	for _, event := range events {
+		if slices.Contains(supported, event) && !slices.Contains(result, event) {
+			result = append(result, event)
+		}
+	}
+	slices.Sort(result)
+	return result
+}
+
+// SameAs 判断 Webhook 的事件、内容类型和启用状态是否与 other 一致，事件只比较 supported 中的部分
+// 平台不返回已有密钥，因此密钥不参与比较
+func (hi *HookInfo) SameAs(other *HookInfo, supported []string) bool {
+	return hi.GetContentType() == other.GetContentType() &&
+		hi.IsActive() == other.IsActive() &&
+		slices.Equal(SupportedEvents(hi.Events, supported), SupportedEvents(other.Events, supported))
+}
+
+// LoadHookTemplates 从 JSON 模板文件读取 Webhook 配置，并从 secret_env 指定的环境变量读取密钥
+// Examples:
+//
+//	[{"url": "https://ci.example.com/hook", "events": ["push", "pull_request"], "secret_env": "CI_HOOK_SECRET"}]
+func LoadHookTemplates(path string) ([]*HookInfo, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var hooks []*HookInfo
+	if err := json.Unmarshal(data, &hooks); err != nil {
+		return nil, fmt.Errorf("parse hook template %s: %w", path, err)
+	}
+	for i, hook := range hooks {
+		hook.URL = strings.TrimSpace(hook.URL)
+		if hook.URL == "" {
+			return nil, fmt.Errorf("hook template %s: entry %d has no url", path, i)
+		}
+		if hook.ContentType != "" && hook.ContentType != HookContentTypeJSON && hook.ContentType != HookContentTypeForm {
+			return nil, fmt.Errorf("hook template %s: invalid content_type %q for %s", path, hook.ContentType, hook.URL)
+		}
+		if hook.SecretEnv != "" {
+			hook.Secret = os.Getenv(hook.SecretEnv)
+			if hook.Secret == "" {
+				return nil, fmt.Errorf("hook template %s: environment variable %s for %s is empty", path, hook.SecretEnv, hook.URL)
+			}
+		}
+	}
+	return hooks, nil
+}
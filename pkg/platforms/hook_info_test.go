@@ -0,0 +1,42 @@
+package platforms
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHookInfoSameAs(t *testing.T) {
+	inactive := false
+	supported := []string{HookEventPush, HookEventTagPush, HookEventPullRequest}
+	hook := &HookInfo{URL: "https://ci.example.com/hook", Events: []string{HookEventPullRequest, HookEventPush, HookEventIssues}}
+	tests := []struct {
+		name  string
+		other *HookInfo
+		want  bool
+	}{
+		{"same", &HookInfo{URL: "https://ci.example.com/hook", ContentType: HookContentTypeJSON, Events: []string{HookEventPush, HookEventPullRequest}}, true},
+		{"unsupported events ignored", &HookInfo{Events: []string{HookEventPush, HookEventPullRequest, HookEventRelease}}, true},
+		{"events differ", &HookInfo{Events: []string{HookEventPush}}, false},
+		{"content type differs", &HookInfo{ContentType: HookContentTypeForm, Events: []string{HookEventPush, HookEventPullRequest}}, false},
+		{"inactive", &HookInfo{Active: &inactive, Events: []string{HookEventPush, HookEventPullRequest}}, false},
+		{"secret ignored", &HookInfo{Secret: "s3cr3t", Events: []string{HookEventPush, HookEventPullRequest}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hook.SameAs(tt.other, supported); got != tt.want {
+				t.Errorf("SameAs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// writeFile 将 content 写入临时目录中的文件并返回路径
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
@@ -0,0 +1,116 @@
+package platforms
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	IssueStateOpen   = "open"   // 打开状态
+	IssueStateClosed = "closed" // 关闭状态
+)
+
+// IssueInfo 表示代码托管平台上的 Issue 信息
+type IssueInfo struct {
+	Number    string    // Issue 编号，GitHub/Gitea 为数字（如 "12"），Gitee 为字符串（如 "I1ABCD"）
+	Title     string    // 标题
+	Body      string    // 内容（Markdown）
+	State     string    // 状态，统一为 IssueStateOpen 或 IssueStateClosed
+	Labels    []string  // 标签名称列表
+	Assignees []string  // 负责人用户名列表
+	Author    string    // 创建者用户名
+	CreatedAt time.Time // 创建时间
+	URL       string    // Issue 的网页地址
+}
+
+// CommentInfo 表示 Issue 下的一条评论
+type CommentInfo struct {
+	ID        int64     // 评论唯一 ID
+	Body      string    // 评论内容（Markdown）
+	Author    string    // 评论者用户名
+	CreatedAt time.Time // 创建时间
+	URL       string    // 评论的网页地址
+	Path      string    // 代码评审评论所在文件，普通评论为空
+	Line      int       // 代码评审评论所在行，未知时为 0
+	Review    bool      // 是否为与普通评论分开编号的代码评审评论
+}
+
+// mpgrm 在迁移后的内容末尾写入隐藏标记，用于重复执行时识别已迁移的 Issue 和评论
+var (
+	issueMarkerRegexp   = regexp.MustCompile(`<!-- mpgrm:issue=(\S+) -->`)
+	commentMarkerRegexp = regexp.MustCompile(`<!-- mpgrm:comment=(r?\d+) -->`)
+)
+
+// IssueMarker 返回源 Issue 编号对应的隐藏标记
+func IssueMarker(number string) string {
+	return fmt.Sprintf("<!-- mpgrm:issue=%s -->", number)
+}
+
+// ParseIssueMarker 从内容中解析源 Issue 编号
+// 标记写在内容末尾，源内容可能引用了其它已迁移 Issue 的标记，因此取最后一个
+func ParseIssueMarker(body string) (string, bool) {
+	return lastMarker(issueMarkerRegexp, body)
+}
+
+// CommentMarker 返回源评论标记 ID（见 CommentInfo.MarkerID）对应的隐藏标记
+func CommentMarker(id string) string {
+	return fmt.Sprintf("<!-- mpgrm:comment=%s -->", id)
+}
+
+// ParseCommentMarker 从内容中解析源评论标记 ID，与 ParseIssueMarker 一样取最后一个标记
+func ParseCommentMarker(body string) (string, bool) {
+	return lastMarker(commentMarkerRegexp, body)
+}
+
+// lastMarker 返回内容中最后一个标记的值
+func lastMarker(re *regexp.Regexp, body string) (string, bool) {
+	matches := re.FindAllStringSubmatch(body, -1)
+	if len(matches) == 0 {
+		return "", false
+	}
+	return matches[len(matches)-1][1], true
+}
+
+// attribution 生成原作者与时间的说明，作者名放在代码格式中，避免在目标平台提及（@）同名用户
+func attribution(action, author string, createdAt time.Time, url string) string {
+	var sb strings.Builder
+	sb.WriteString("> Originally " + action)
+	if author != "" {
+		sb.WriteString(" by `" + author + "`")
+	}
+	if !createdAt.IsZero() {
+		sb.WriteString(" on " + createdAt.UTC().Format("2006-01-02 15:04 MST"))
+	}
+	if url != "" {
+		sb.WriteString(" at " + url)
+	}
+	return sb.String()
+}
+
+// AttributedBody 返回带原作者、时间和迁移标记的 Issue 内容
+func (ii *IssueInfo) AttributedBody() string {
+	return fmt.Sprintf("%s\n\n%s\n\n%s", attribution("opened", ii.Author, ii.CreatedAt, ii.URL), ii.Body, IssueMarker(ii.Number))
+}
+
+// AttributedBody 返回带原作者、时间和迁移标记的评论内容，代码评审评论会注明文件和行号
+func (ci *CommentInfo) AttributedBody() string {
+	header := attribution("posted", ci.Author, ci.CreatedAt, ci.URL)
+	if ci.Path != "" {
+		location := ci.Path
+		if ci.Line > 0 {
+			location = fmt.Sprintf("%s:%d", ci.Path, ci.Line)
+		}
+		header += fmt.Sprintf("\n> On `%s`", location)
+	}
+	return fmt.Sprintf("%s\n\n%s\n\n%s", header, ci.Body, CommentMarker(ci.MarkerID()))
+}
+
+// MarkerID 返回迁移标记中的评论 ID，代码评审评论与普通评论的 ID 可能相同，因此加上前缀 r
+func (ci *CommentInfo) MarkerID() string {
+	if ci.Review {
+		return fmt.Sprintf("r%d", ci.ID)
+	}
+	return fmt.Sprintf("%d", ci.ID)
+}
@@ -0,0 +1,41 @@
+package platforms
+
+import "testing"
+
+func TestParseMarkers(t *testing.T) {
+	tests := []struct {
+		name   string
+		parse  func(string) (string, bool)
+		body   string
+		want   string
+		wantOK bool
+	}{
+		{"issue", ParseIssueMarker, "text\n\n" + IssueMarker("I1ABCD"), "I1ABCD", true},
+		{"issue none", ParseIssueMarker, "text without marker", "", false},
+		{"issue quoted marker", ParseIssueMarker, "> " + IssueMarker("3") + "\n\n" + IssueMarker("12"), "12", true},
+		{"pull", ParsePullMarker, "text\n\n" + PullMarker("7"), "7", true},
+		{"pull quoted marker", ParsePullMarker, "see " + PullMarker("1") + "\n\n" + PullMarker("7"), "7", true},
+		{"pull ignores issue marker", ParsePullMarker, IssueMarker("7"), "", false},
+		{"comment", ParseCommentMarker, "text\n\n" + CommentMarker("42"), "42", true},
+		{"review comment", ParseCommentMarker, "text\n\n" + CommentMarker("r42"), "r42", true},
+		{"comment quoted marker", ParseCommentMarker, CommentMarker("1") + "\n\n" + CommentMarker("r2"), "r2", true},
+		{"comment invalid id", ParseCommentMarker, "<!-- mpgrm:comment=x1 -->", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.parse(tt.body)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("parse(%q) = %q, %v, want %q, %v", tt.body, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestCommentMarkerID(t *testing.T) {
+	if got := (&CommentInfo{ID: 42}).MarkerID(); got != "42" {
+		t.Errorf("MarkerID() = %q, want 42", got)
+	}
+	if got := (&CommentInfo{ID: 42, Review: true}).MarkerID(); got != "r42" {
+		t.Errorf("MarkerID() = %q, want r42", got)
+	}
+}
@@ -0,0 +1,57 @@
+package platforms
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	MilestoneStateOpen   = "open"   // 打开状态
+	MilestoneStateClosed = "closed" // 关闭状态
+)
+
+// LabelInfo 表示仓库中的 Issue 标签
+type LabelInfo struct {
+	ID          int64  // 标签在平台中的 ID，仅用于更新，部分平台为 0
+	Name        string // 标签名称，平台间按名称（忽略大小写）匹配
+	Color       string // 颜色，统一为不带 "#" 的小写六位十六进制，例如: "d73a4a"
+	Description string // 描述，Gitee 不支持
+}
+
+// MilestoneInfo 表示仓库中的里程碑
+type MilestoneInfo struct {
+	Number      string    // 里程碑在平台中的编号或 ID，仅用于更新
+	Title       string    // 标题，平台间按标题匹配
+	Description string    // 描述
+	State       string    // 状态，统一为 MilestoneStateOpen 或 MilestoneStateClosed
+	DueOn       time.Time // 截止日期，未设置时为零值
+}
+
+// NormalizeColor 将颜色统一为不带 "#" 的小写六位十六进制
+// Examples:
+//
+//	"#D73A4A" -> "d73a4a"
+//	"d73a4a"  -> "d73a4a"
+func NormalizeColor(color string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(color), "#"))
+}
+
+// LabelKey 返回用于平台间匹配标签的名称
+func LabelKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// SameAs 判断标签颜色和描述是否与 other 一致，supportsDescription 为 false 时忽略描述
+func (li *LabelInfo) SameAs(other *LabelInfo, supportsDescription bool) bool {
+	if NormalizeColor(li.Color) != NormalizeColor(other.Color) {
+		return false
+	}
+	return !supportsDescription || li.Description == other.Description
+}
+
+// SameAs 判断里程碑描述、状态和截止日期是否与 other 一致，截止日期只比较日期部分
+func (mi *MilestoneInfo) SameAs(other *MilestoneInfo) bool {
+	return mi.Description == other.Description &&
+		mi.State == other.State &&
+		mi.DueOn.UTC().Format(time.DateOnly) == other.DueOn.UTC().Format(time.DateOnly)
+}
@@ -0,0 +1,25 @@
+package platforms
+
+import "testing"
+
+func TestLabelInfoSameAs(t *testing.T) {
+	label := &LabelInfo{Name: "bug", Color: "#D73A4A", Description: "Something is broken"}
+	tests := []struct {
+		name                string
+		other               *LabelInfo
+		supportsDescription bool
+		want                bool
+	}{
+		{"same", &LabelInfo{Name: "Bug", Color: "d73a4a", Description: "Something is broken"}, true, true},
+		{"color differs", &LabelInfo{Name: "bug", Color: "ff0000", Description: "Something is broken"}, true, false},
+		{"description differs", &LabelInfo{Name: "bug", Color: "d73a4a"}, true, false},
+		{"description unsupported", &LabelInfo{Name: "bug", Color: "d73a4a"}, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := label.SameAs(tt.other, tt.supportsDescription); got != tt.want {
+				t.Errorf("SameAs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package platforms
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// 统一的仓库权限，各平台在实现中与自身的角色互相转换
+const (
+	PermissionRead  = "read"  // 只读，可以克隆和评论
+	PermissionWrite = "write" // 可以推送
+	PermissionAdmin = "admin" // 可以管理仓库设置
+)
+
+// CollaboratorInfo 表示仓库的协作者及其权限
+type CollaboratorInfo struct {
+	Username   string // 用户名
+	Permission string // 统一的权限，PermissionRead、PermissionWrite 或 PermissionAdmin
+}
+
+// TeamInfo 表示组织中的团队
+type TeamInfo struct {
+	ID          int64    // 团队在平台中的 ID
+	Slug        string   // 团队在 URL 中的标识，GitHub 使用
+	Name        string   // 团队名称，平台间按名称匹配
+	Description string   // 团队描述
+	Permission  string   // 团队对其仓库的统一权限
+	Members     []string // 成员用户名
+	Repos       []string // 团队可访问的仓库名（不含组织）
+}
+
+// ComparePermission 比较两个统一权限的高低，a 高于 b 时返回正数
+func ComparePermission(a, b string) int {
+	rank := map[string]int{PermissionRead: 1, PermissionWrite: 2, PermissionAdmin: 3}
+	return rank[a] - rank[b]
+}
+
+// UserMapping 保存源平台用户名到目标平台用户名的映射
+type UserMapping struct {
+	users        map[string]string
+	keepUnmapped bool
+}
+
+// NewUserMapping 创建用户映射，keepUnmapped 为 true 时未映射的用户沿用原用户名
+func NewUserMapping(users map[string]string, keepUnmapped bool) *UserMapping {
+	normalized := make(map[string]string, len(users))
+	for source, target := range users {
+		normalized[strings.ToLower(strings.TrimSpace(source))] = strings.TrimSpace(target)
+	}
+	return &UserMapping{users: normalized, keepUnmapped: keepUnmapped}
+}
+
+// LoadUserMapping 从 JSON 文件读取用户映射，映射为空字符串的用户会被跳过
+// Examples:
+//
+//	{"octocat": "octo-gitea", "bot": ""}
+func LoadUserMapping(path string, keepUnmapped bool) (*UserMapping, error) {
+	users := map[string]string{}
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &users); err != nil {
+			return nil, fmt.Errorf("parse user mapping %s: %w", path, err)
+		}
+	}
+	return NewUserMapping(users, keepUnmapped), nil
+}
+
+// Map 返回源用户在目标平台的用户名，无法映射时 ok 为 false
+func (um *UserMapping) Map(username string) (string, bool) {
+	target, found := um.users[strings.ToLower(username)]
+	if !found {
+		if um.keepUnmapped {
+			return username, true
+		}
+		return "", false
+	}
+	return target, target != ""
+}
@@ -0,0 +1,12 @@
+package platforms
+
+import "time"
+
+// MirrorInfo 描述服务端拉取镜像的来源仓库
+type MirrorInfo struct {
+	CloneURL string        // 源仓库的 HTTPS 克隆地址
+	Username string        // 访问源仓库的用户名
+	Password string        // 访问源仓库的密码或访问令牌
+	Interval time.Duration // 服务端同步间隔
+	Wiki     bool          // 是否同时镜像 Wiki
+}
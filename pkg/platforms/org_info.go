@@ -0,0 +1,10 @@
+package platforms
+
+// OrgInfo 表示组织的基本信息
+type OrgInfo struct {
+	Name        string // 组织路径，例如: "org" 或 CNB 的 "org/child"
+	DisplayName string // 组织显示名称
+	Description string // 组织描述
+	Homepage    string // 组织主页
+	IsPrivate   bool   // 是否仅对成员可见
+}
@@ -0,0 +1,70 @@
+package platforms
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ProtectionRule 是平台无关的分支保护规则，各平台在实现中转换为自身的保护设置
+// 平台不支持的字段无法生效，SetProtection 应用其余设置后返回 UnenforcedError
+type ProtectionRule struct {
+	Branch              string   `json:"branch"`                          // 分支名或通配符，例如: "main"、"release/*"
+	RequiredReviews     int      `json:"required_reviews,omitempty"`      // 合并前需要的批准数，大于 0 时禁止直接推送
+	DismissStaleReviews bool     `json:"dismiss_stale_reviews,omitempty"` // 推送新提交后作废已有批准
+	StatusChecks        []string `json:"status_checks,omitempty"`         // 合并前必须通过的状态检查
+	RestrictPush        bool     `json:"restrict_push,omitempty"`         // 只允许 PushUsers/PushTeams 推送
+	PushUsers           []string `json:"push_users,omitempty"`            // 允许推送的用户
+	PushTeams           []string `json:"push_teams,omitempty"`            // 允许推送的团队
+	BlockForcePush      bool     `json:"block_force_push,omitempty"`      // 禁止强制推送
+	BlockDeletion       bool     `json:"block_deletion,omitempty"`        // 禁止删除分支
+	EnforceAdmins       bool     `json:"enforce_admins,omitempty"`        // 规则同样约束管理员
+}
+
+// UnenforcedError 表示规则已应用，但目标平台无法执行其中的部分设置
+type UnenforcedError struct {
+	Fields []string // 未生效的设置，与 ProtectionRule 的 JSON 字段名一致
+}
+
+func (e *UnenforcedError) Error() string {
+	return fmt.Sprintf("applied without %s, not supported by the platform", strings.Join(e.Fields, ", "))
+}
+
+// Unenforced 在 fields 非空时返回 UnenforcedError，否则返回 nil
+func Unenforced(fields ...string) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	return &UnenforcedError{Fields: fields}
+}
+
+// IsPattern 判断规则是否使用通配符
+func (pr *ProtectionRule) IsPattern() bool {
+	return strings.ContainsAny(pr.Branch, "*?[")
+}
+
+// LoadProtectionRules 从 JSON 策略文件读取分支保护规则
+// Examples:
+//
+//	[{"branch": "main", "required_reviews": 1, "status_checks": ["ci"], "block_force_push": true, "block_deletion": true}]
+func LoadProtectionRules(path string) ([]*ProtectionRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var rules []*ProtectionRule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("parse protection policy %s: %w", path, err)
+	}
+	for i, rule := range rules {
+		rule.Branch = strings.TrimSpace(rule.Branch)
+		if rule.Branch == "" {
+			return nil, fmt.Errorf("protection policy %s: entry %d has no branch", path, i)
+		}
+		if rule.RequiredReviews < 0 {
+			return nil, fmt.Errorf("protection policy %s: negative required_reviews for %s", path, rule.Branch)
+		}
+	}
+	return rules, nil
+}
@@ -0,0 +1,41 @@
+package platforms
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLoadProtectionRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []*ProtectionRule
+		wantErr bool
+	}{
+		{
+			name:    "rules",
+			content: `[{"branch": " main ", "required_reviews": 1, "status_checks": ["ci"], "block_force_push": true}, {"branch": "release/*", "block_deletion": true}]`,
+			want: []*ProtectionRule{
+				{Branch: "main", RequiredReviews: 1, StatusChecks: []string{"ci"}, BlockForcePush: true},
+				{Branch: "release/*", BlockDeletion: true},
+			},
+		},
+		{name: "missing branch", content: `[{"required_reviews": 1}]`, wantErr: true},
+		{name: "negative reviews", content: `[{"branch": "main", "required_reviews": -1}]`, wantErr: true},
+		{name: "invalid json", content: `{"branch": "main"}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LoadProtectionRules(writeFile(t, tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadProtectionRules() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LoadProtectionRules() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+	if !(&ProtectionRule{Branch: "release/*"}).IsPattern() || (&ProtectionRule{Branch: "main"}).IsPattern() {
+		t.Error("IsPattern() did not detect the wildcard")
+	}
+}
@@ -0,0 +1,113 @@
+package platforms
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	PullStateOpen   = "open"   // 打开状态
+	PullStateClosed = "closed" // 未合并即关闭
+	PullStateMerged = "merged" // 已合并
+)
+
+// PullInfo 表示代码托管平台上的合并请求（Pull Request）信息
+type PullInfo struct {
+	Number    string    // 合并请求编号
+	Title     string    // 标题
+	Body      string    // 内容（Markdown）
+	State     string    // 状态，统一为 PullStateOpen、PullStateClosed 或 PullStateMerged
+	Head      string    // 源分支名
+	HeadSHA   string    // 源分支最新提交
+	Base      string    // 目标分支名
+	Author    string    // 创建者用户名
+	CreatedAt time.Time // 创建时间
+	MergedAt  time.Time // 合并时间，未合并时为零值
+	URL       string    // 合并请求的网页地址
+}
+
+// PullFileInfo 表示合并请求中变更的文件
+type PullFileInfo struct {
+	Filename  string // 文件路径
+	Status    string // 变更类型，例如: added、modified、removed、renamed
+	Additions int    // 新增行数
+	Deletions int    // 删除行数
+}
+
+var pullMarkerRegexp = regexp.MustCompile(`<!-- mpgrm:pull=(\S+) -->`)
+
+// PullMarker 返回源合并请求编号对应的隐藏标记
+func PullMarker(number string) string {
+	return fmt.Sprintf("<!-- mpgrm:pull=%s -->", number)
+}
+
+// ParsePullMarker 从内容中解析源合并请求编号，与 ParseIssueMarker 一样取最后一个标记
+func ParsePullMarker(body string) (string, bool) {
+	return lastMarker(pullMarkerRegexp, body)
+}
+
+// PullRef 返回源仓库中合并请求头部提交的引用，例如: "refs/pull/12/head"
+func PullRef(number string) string {
+	return fmt.Sprintf("refs/pull/%s/head", number)
+}
+
+// PullBranch 返回合并请求头部在目标仓库中镜像的分支名，例如: "mpgrm/pr-12"
+func PullBranch(number string) string {
+	return "mpgrm/pr-" + number
+}
+
+// status 返回合并状态的说明
+func (pi *PullInfo) status() string {
+	switch pi.State {
+	case PullStateMerged:
+		if !pi.MergedAt.IsZero() {
+			return "merged on " + pi.MergedAt.UTC().Format("2006-01-02 15:04 MST")
+		}
+		return "merged"
+	case PullStateClosed:
+		return "closed without merging"
+	default:
+		return "open"
+	}
+}
+
+// AttributedBody 返回重建为原生合并请求时使用的内容，包含原作者、时间、合并状态和迁移标记
+func (pi *PullInfo) AttributedBody() string {
+	return fmt.Sprintf("%s\n> Status: %s, `%s` → `%s`\n\n%s\n\n%s",
+		attribution("opened", pi.Author, pi.CreatedAt, pi.URL), pi.status(), pi.Head, pi.Base, pi.Body, PullMarker(pi.Number))
+}
+
+// IssueTitle 返回以只读 Issue 形式归档时的标题
+func (pi *PullInfo) IssueTitle() string {
+	return fmt.Sprintf("[PR #%s] %s", pi.Number, pi.Title)
+}
+
+// IssueBody 返回以只读 Issue 形式归档时的内容，包含合并状态和变更文件摘要
+func (pi *PullInfo) IssueBody(files []*PullFileInfo) string {
+	var sb strings.Builder
+	sb.WriteString(attribution("opened", pi.Author, pi.CreatedAt, pi.URL))
+	sb.WriteString(fmt.Sprintf("\n> Status: %s, `%s` → `%s`", pi.status(), pi.Head, pi.Base))
+	if pi.HeadSHA != "" {
+		sb.WriteString(fmt.Sprintf(", head `%s`", pi.HeadSHA))
+	}
+	sb.WriteString("\n\n")
+	sb.WriteString(pi.Body)
+	if len(files) > 0 {
+		var additions, deletions int
+		for _, file := range files {
+			additions += file.Additions
+			deletions += file.Deletions
+		}
+		sb.WriteString(fmt.Sprintf("\n\n<details><summary>%d files changed, +%d -%d</summary>\n\n", len(files), additions, deletions))
+		sb.WriteString("| File | Status | + | - |\n| --- | --- | --- | --- |\n")
+		for _, file := range files {
+			sb.WriteString(fmt.Sprintf("| `%s` | %s | %d | %d |\n", file.Filename, file.Status, file.Additions, file.Deletions))
+		}
+		sb.WriteString("\n</details>")
+	}
+	sb.WriteString("\n\n")
+	sb.WriteString(PullMarker(pi.Number))
+	return sb.String()
+}
@@ -28,8 +28,12 @@ type AssetInfo struct {
 	ID   int64  // 附件的唯一 ID（由平台生成），用于删除或引用
 	Name string // 附件的名称（如文件名），如 "app-v1.0.0-linux-amd64.tar.gz"
 	URL  string // 附件的下载 URL（通常是公开链接或 API 地址）
+	Size int64  // 附件大小（字节），平台不提供时为 UnknownSize
 }
 
+// UnknownSize 表示平台的接口不返回附件大小
+const UnknownSize int64 = -1
+
 func (ri *ReleaseInfo) Init() {
 	if ri.Title == "" {
 		ri.Title = ri.TagName
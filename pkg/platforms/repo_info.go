@@ -3,6 +3,8 @@ package platforms
 import (
 	"fmt"
 	"github.com/chihqiang/mpgrm/pkg/x"
+	"slices"
+	"strings"
 )
 
 // RepoInfo 表示代码托管平台上的仓库信息
@@ -14,6 +16,106 @@ type RepoInfo struct {
 	Homepage    string // 仓库主页链接，例如: "https://example.com"
 	IsPrivate   bool   // 是否为私有仓库，例如: true
 	CloneURL    string // 克隆仓库的 URL（HTTPS 或 SSH），例如: "https://github.com/my-org/my-repo.git"
+
+	DefaultBranch string   // 默认分支，例如: "main"
+	IsArchived    bool     // 是否已归档（只读），例如: false
+	Topics        []string // 仓库主题/标签，例如: ["go", "cli"]
+	IsMirror      bool     // 是否为服务端定期拉取的镜像仓库
+
+	License  string // 许可证 SPDX 标识，例如: "MIT"
+	Language string // 主要编程语言，例如: "Go"
+	Stars    int    // 星标数
+	Forks    int    // 派生（Fork）数
+	Size     int64  // 仓库大小（KB），平台不提供时为 0
+}
+
+// RepoField 表示可在平台间同步的仓库元数据字段
+type RepoField string
+
+const (
+	RepoFieldDescription   RepoField = "description"
+	RepoFieldHomepage      RepoField = "homepage"
+	RepoFieldTopics        RepoField = "topics"
+	RepoFieldDefaultBranch RepoField = "default-branch"
+	RepoFieldVisibility    RepoField = "visibility"
+	RepoFieldArchived      RepoField = "archived"
+)
+
+// RepoFields 为所有支持同步的元数据字段
+var RepoFields = []RepoField{
+	RepoFieldDescription,
+	RepoFieldHomepage,
+	RepoFieldTopics,
+	RepoFieldDefaultBranch,
+	RepoFieldVisibility,
+	RepoFieldArchived,
+}
+
+// ParseRepoFields 解析元数据字段名，"none" 表示不同步任何字段，"all" 表示同步全部字段
+func ParseRepoFields(names []string) ([]RepoField, error) {
+	var fields []RepoField
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch {
+		case name == "none":
+			return nil, nil
+		case name == "all":
+			return RepoFields, nil
+		case slices.Contains(RepoFields, RepoField(name)):
+			fields = append(fields, RepoField(name))
+		default:
+			return nil, fmt.Errorf("unknown repository field %q, supported: %v", name, RepoFields)
+		}
+	}
+	return fields, nil
+}
+
+// MergeMetadata 以 ri（目标仓库当前状态）为基础，用 source 中 fields 指定的字段覆盖，
+// 返回期望的目标仓库信息以及与当前状态相比是否有变化
+func (ri *RepoInfo) MergeMetadata(source *RepoInfo, fields []RepoField) (*RepoInfo, bool) {
+	desired := *ri
+	desired.Topics = slices.Clone(ri.Topics)
+	for _, field := range fields {
+		switch field {
+		case RepoFieldDescription:
+			desired.Description = source.Description
+		case RepoFieldHomepage:
+			desired.Homepage = source.Homepage
+		case RepoFieldTopics:
+			desired.Topics = slices.Clone(source.Topics)
+		case RepoFieldDefaultBranch:
+			if source.DefaultBranch != "" {
+				desired.DefaultBranch = source.DefaultBranch
+			}
+		case RepoFieldVisibility:
+			desired.IsPrivate = source.IsPrivate
+		case RepoFieldArchived:
+			desired.IsArchived = source.IsArchived
+		}
+	}
+	changed := desired.Description != ri.Description ||
+		desired.Homepage != ri.Homepage ||
+		desired.DefaultBranch != ri.DefaultBranch ||
+		desired.IsPrivate != ri.IsPrivate ||
+		desired.IsArchived != ri.IsArchived ||
+		!sameTopics(desired.Topics, ri.Topics)
+	return &desired, changed
+}
+
+// sameTopics 忽略顺序和大小写比较两组主题
+func sameTopics(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	normalize := func(topics []string) []string {
+		n := make([]string, len(topics))
+		for i, t := range topics {
+			n[i] = strings.ToLower(t)
+		}
+		slices.Sort(n)
+		return n
+	}
+	return slices.Equal(normalize(a), normalize(b))
 }
 
 // GetOwnerRepo extracts the repository owner and repository name from FullName.
@@ -0,0 +1,83 @@
+package platforms
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRepoFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		names   []string
+		want    []RepoField
+		wantErr bool
+	}{
+		{"empty", nil, nil, false},
+		{"some", []string{" Description", "topics "}, []RepoField{RepoFieldDescription, RepoFieldTopics}, false},
+		{"all", []string{"description", "all"}, RepoFields, false},
+		{"none", []string{"none", "topics"}, nil, false},
+		{"unknown", []string{"description", "stars"}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRepoFields(tt.names)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRepoFields() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseRepoFields() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeMetadata(t *testing.T) {
+	target := &RepoInfo{Name: "app", Description: "old", Homepage: "https://old.example.com", Topics: []string{"Go", "cli"}, DefaultBranch: "master", IsPrivate: true}
+	source := &RepoInfo{Name: "app", Description: "new", Homepage: "https://example.com", Topics: []string{"cli", "go"}, DefaultBranch: "main", IsArchived: true}
+	tests := []struct {
+		name        string
+		source      *RepoInfo
+		fields      []RepoField
+		want        *RepoInfo
+		wantChanged bool
+	}{
+		{
+			name:   "no fields",
+			source: source,
+			want:   target,
+		},
+		{
+			name:   "topics ignore order and case",
+			source: source,
+			fields: []RepoField{RepoFieldTopics},
+			want:   &RepoInfo{Name: "app", Description: "old", Homepage: "https://old.example.com", Topics: []string{"cli", "go"}, DefaultBranch: "master", IsPrivate: true},
+		},
+		{
+			name:        "all fields",
+			source:      source,
+			fields:      RepoFields,
+			want:        &RepoInfo{Name: "app", Description: "new", Homepage: "https://example.com", Topics: []string{"cli", "go"}, DefaultBranch: "main", IsArchived: true},
+			wantChanged: true,
+		},
+		{
+			name:   "empty default branch is kept",
+			source: &RepoInfo{Description: "old"},
+			fields: []RepoField{RepoFieldDescription, RepoFieldDefaultBranch},
+			want:   target,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changed := target.MergeMetadata(tt.source, tt.fields)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MergeMetadata() = %+v, want %+v", got, tt.want)
+			}
+			if changed != tt.wantChanged {
+				t.Errorf("MergeMetadata() changed = %v, want %v", changed, tt.wantChanged)
+			}
+		})
+	}
+	if !reflect.DeepEqual(target.Topics, []string{"Go", "cli"}) {
+		t.Errorf("MergeMetadata() modified the target topics: %v", target.Topics)
+	}
+}
@@ -0,0 +1,98 @@
+package report
+
+import (
+	"encoding/json"
+	"github.com/chihqiang/mpgrm/pkg/metrics"
+	"github.com/chihqiang/mpgrm/pkg/x"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	StatusSuccess = "success" // 同步成功
+	StatusFailed  = "failed"  // 同步失败
+)
+
+// Result 为单个仓库的同步结果
+type Result struct {
+	Repo     string `json:"repo"`             // 源仓库或目标仓库的完整名称、地址
+	Target   string `json:"target,omitempty"` // 目标仓库地址，与 Repo 相同时为空
+	Status   string `json:"status"`           // StatusSuccess 或 StatusFailed
+	Error    string `json:"error,omitempty"`  // 失败原因
+	Duration string `json:"duration"`         // 处理耗时
+}
+
+// Report 为一次命令执行的结果报告
+type Report struct {
+	Args       []string  `json:"args"`              // 命令行参数
+	StartedAt  time.Time `json:"started_at"`        // 开始时间
+	FinishedAt time.Time `json:"finished_at"`       // 结束时间
+	Error      string    `json:"error,omitempty"`   // 命令整体的失败原因
+	Results    []Result  `json:"results"`           // 各仓库的结果
+	Metrics    string    `json:"metrics,omitempty"` // 本次执行的指标（Prometheus 文本格式），serve 模式汇总到 /metrics
+}
+
+var (
+	mu      sync.Mutex
+	started = time.Now()
+	results = make([]Result, 0)
+)
+
+// Add 记录一个仓库的同步结果，err 为 nil 时视为成功，地址中的用户名和密码会被隐藏
+func Add(repo, target string, start time.Time, err error) {
+	result := Result{Repo: x.RedactURLs(repo), Status: StatusSuccess, Duration: time.Since(start).Round(time.Millisecond).String()}
+	if target != repo {
+		result.Target = x.RedactURLs(target)
+	}
+	if err != nil {
+		result.Status = StatusFailed
+		result.Error = x.RedactURLs(err.Error())
+	}
+	metrics.ReposTotal.WithLabelValues(result.Status).Inc()
+	metrics.RepoDuration.WithLabelValues().Observe(time.Since(start).Seconds())
+	mu.Lock()
+	defer mu.Unlock()
+	results = append(results, result)
+}
+
+// Results 返回已记录的结果
+func Results() []Result {
+	mu.Lock()
+	defer mu.Unlock()
+	return append([]Result{}, results...)
+}
+
+// Write 将报告以 JSON 格式写入 path，runErr 为命令整体的执行结果
+func Write(path string, runErr error) error {
+	r := Report{Args: make([]string, 0, len(os.Args)), StartedAt: started, FinishedAt: time.Now(), Results: Results()}
+	for _, arg := range os.Args[1:] {
+		r.Args = append(r.Args, x.RedactURLs(arg))
+	}
+	r.Metrics, _ = metrics.Snapshot()
+	if runErr != nil {
+		r.Error = x.RedactURLs(runErr.Error())
+	}
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// Read 读取 Write 写入的报告
+func Read(path string) (*Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var r Report
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
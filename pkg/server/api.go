@@ -0,0 +1,205 @@
+package server
+
+import (
+	"bufio"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/chihqiang/mpgrm/pkg/logx"
+	"github.com/chihqiang/mpgrm/pkg/report"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+)
+
+// filterCommands 为支持 --filter 的子命令，可以只同步其中一个仓库
+var filterCommands = [][]string{{"repo", "sync"}, {"keys", "sync"}}
+
+// JobDetail 为任务状态及其执行记录
+type JobDetail struct {
+	JobStatus
+	History []Run `json:"history"`
+}
+
+// RunDetail 为执行记录及其各仓库结果
+type RunDetail struct {
+	Run
+	Report *report.Report `json:"report,omitempty"`
+}
+
+// triggerRequest 为手动触发的请求体
+type triggerRequest struct {
+	Repo string `json:"repo,omitempty"` // 只同步匹配的仓库，例如 "org/app"
+}
+
+// registerAPI 注册管理接口，未配置 api_token_env 时不注册，避免任何人都能触发或取消任务
+func (s *Server) registerAPI(mux *http.ServeMux) {
+	if s.config.APITokenEnv == "" {
+		logx.Warn("Management API disabled: set api_token_env in the serve config to enable /api")
+		return
+	}
+	mux.HandleFunc("GET /api/jobs", s.authorize(s.apiListJobs))
+	mux.HandleFunc("GET /api/jobs/{job}", s.authorize(s.apiGetJob))
+	mux.HandleFunc("POST /api/jobs/{job}/runs", s.authorize(s.apiTrigger))
+	mux.HandleFunc("POST /api/jobs/{job}/cancel", s.authorize(s.apiCancel))
+	mux.HandleFunc("GET /api/jobs/{job}/runs/{id}", s.authorize(s.apiGetRun))
+	mux.HandleFunc("GET /api/jobs/{job}/runs/{id}/log", s.authorize(s.apiRunLog))
+}
+
+// Trigger 立即执行指定任务，repo 不为空时只同步匹配的仓库
+// 任务正在执行时排队到当前执行结束后，并返回 nil
+func (s *Server) Trigger(name, repo string) (*Run, error) {
+	job, ok := s.byName[name]
+	if !ok {
+		return nil, ErrJobNotFound
+	}
+	args := job.Args
+	if repo != "" {
+		if !supportsFilter(job.Args) {
+			return nil, fmt.Errorf("job %s does not support syncing a single repository", name)
+		}
+		args = append(slices.Clone(job.Args), "--filter", repo)
+	}
+	return s.start(job, TriggerManual, args, true)
+}
+
+// authorize 校验 api_token_env 中的 Bearer 令牌，环境变量为空时拒绝所有请求
+func (s *Server) authorize(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := os.Getenv(s.config.APITokenEnv)
+		if token == "" || subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte("Bearer "+token)) != 1 {
+			writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (s *Server) apiListJobs(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.Status())
+}
+
+func (s *Server) apiGetJob(w http.ResponseWriter, r *http.Request) {
+	job, ok := s.pathJob(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, JobDetail{JobStatus: job.Status(), History: job.History()})
+}
+
+func (s *Server) apiTrigger(w http.ResponseWriter, r *http.Request) {
+	job, ok := s.pathJob(w, r)
+	if !ok {
+		return
+	}
+	var req triggerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+	run, err := s.Trigger(job.Name, req.Repo)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+	if run == nil {
+		writeJSON(w, http.StatusAccepted, map[string]string{"status": "queued", "job": job.Name})
+		return
+	}
+	writeJSON(w, http.StatusAccepted, run)
+}
+
+func (s *Server) apiCancel(w http.ResponseWriter, r *http.Request) {
+	job, ok := s.pathJob(w, r)
+	if !ok {
+		return
+	}
+	if !job.Cancel() {
+		writeJSON(w, http.StatusConflict, map[string]string{"error": "job is not running"})
+		return
+	}
+	writeJSON(w, http.StatusAccepted, map[string]string{"status": "canceling", "job": job.Name})
+}
+
+// apiGetRun 返回执行记录及各仓库结果，?repo= 只返回地址包含该名称的仓库
+func (s *Server) apiGetRun(w http.ResponseWriter, r *http.Request) {
+	run, ok := s.pathRun(w, r)
+	if !ok {
+		return
+	}
+	detail := RunDetail{Run: run}
+	if rep, err := report.Read(run.ReportFile); err == nil {
+		if repo := r.URL.Query().Get("repo"); repo != "" {
+			rep.Results = slices.DeleteFunc(rep.Results, func(result report.Result) bool {
+				return !strings.Contains(result.Repo, repo) && !strings.Contains(result.Target, repo)
+			})
+		}
+		rep.Metrics = ""
+		detail.Report = rep
+	}
+	writeJSON(w, http.StatusOK, detail)
+}
+
+// apiRunLog 返回执行日志，?repo= 只返回包含该名称的行
+func (s *Server) apiRunLog(w http.ResponseWriter, r *http.Request) {
+	run, ok := s.pathRun(w, r)
+	if !ok {
+		return
+	}
+	file, err := os.Open(run.LogFile)
+	if err != nil {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "log not found"})
+		return
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	repo := r.URL.Query().Get("repo")
+	if repo == "" {
+		_, _ = io.Copy(w, file)
+		return
+	}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if line := scanner.Text(); strings.Contains(line, repo) {
+			_, _ = fmt.Fprintln(w, line)
+		}
+	}
+}
+
+// pathJob 返回路径中的任务，不存在时写入 404
+func (s *Server) pathJob(w http.ResponseWriter, r *http.Request) (*Job, bool) {
+	job, ok := s.byName[r.PathValue("job")]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": ErrJobNotFound.Error()})
+	}
+	return job, ok
+}
+
+// pathRun 返回路径中的执行记录，不存在时写入 404
+func (s *Server) pathRun(w http.ResponseWriter, r *http.Request) (Run, bool) {
+	job, ok := s.pathJob(w, r)
+	if !ok {
+		return Run{}, false
+	}
+	run, ok := job.FindRun(r.PathValue("id"))
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "run not found"})
+	}
+	return run, ok
+}
+
+// supportsFilter 判断任务的子命令是否支持 --filter
+func supportsFilter(args []string) bool {
+	for _, command := range filterCommands {
+		if len(args) >= len(command) && slices.Equal(args[:len(command)], command) {
+			return true
+		}
+	}
+	return false
+}
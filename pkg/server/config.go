@@ -0,0 +1,135 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/chihqiang/mpgrm/pkg/x"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// jobNamePattern 限制任务名称，任务名会用于日志目录和 URL
+var jobNamePattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// Config 为 serve 模式的配置文件
+type Config struct {
+	Listen      string       `json:"listen,omitempty"`        // HTTP 接口监听地址，例如 ":8080"，为空时不启动
+	APITokenEnv string       `json:"api_token_env,omitempty"` // 保存管理接口访问令牌的环境变量名，为空时不提供管理接口
+	Jobs        []*JobConfig `json:"jobs"`                    // 定时同步任务
+}
+
+// JobConfig 描述一个定时执行的 mpgrm 命令
+type JobConfig struct {
+	Name       string            `json:"name"`                   // 任务名称，全局唯一
+	Schedule   string            `json:"schedule"`               // cron 表达式，例如 "0 */2 * * *" 或 "@every 30m"
+	Args       []string          `json:"args"`                   // mpgrm 子命令及参数，例如 ["repo", "sync", "--repo", "..."]
+	Env        map[string]string `json:"env,omitempty"`          // 额外的环境变量，例如各任务不同的访问令牌
+	Timeout    string            `json:"timeout,omitempty"`      // 单次执行的超时时间，例如 "2h"，为空时不限制
+	RunOnStart bool              `json:"run_on_start,omitempty"` // 启动后立即执行一次
+	Webhook    *WebhookConfig    `json:"webhook,omitempty"`      // 收到推送事件时立即同步对应仓库，为空时不接收
+}
+
+// WebhookConfig 描述任务如何响应源平台的 Webhook
+type WebhookConfig struct {
+	Repos       []string `json:"repos,omitempty"`        // 匹配的源仓库，例如 ["org/*"]，为空时匹配所有仓库
+	TargetRepo  string   `json:"target_repo"`            // 目标仓库地址，以 "/" 结尾时追加源仓库名称
+	TargetRepos []string `json:"target_repos,omitempty"` // 更多目标仓库地址，源仓库只拉取一次后推送到所有目标
+	SecretEnv   string   `json:"secret_env"`             // 保存签名密钥的环境变量名
+}
+
+// LoadConfig 读取并校验 serve 配置文件
+// Examples:
+//
+//	{"listen": ":8080", "jobs": [{"name": "github", "schedule": "@hourly", "args": ["repo", "sync", "--repo", "https://github.com/org/", "--target-repo", "https://gitea.com/org/"]}]}
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config Config
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("parse serve config %s: %w", path, err)
+	}
+	if len(config.Jobs) == 0 {
+		return nil, fmt.Errorf("serve config %s has no jobs", path)
+	}
+	names := make(map[string]bool, len(config.Jobs))
+	for i, job := range config.Jobs {
+		if !jobNamePattern.MatchString(job.Name) || job.Name == "." || job.Name == ".." {
+			return nil, fmt.Errorf("serve config %s: job %d has invalid name %q", path, i, job.Name)
+		}
+		if names[job.Name] {
+			return nil, fmt.Errorf("serve config %s: duplicate job name %q", path, job.Name)
+		}
+		names[job.Name] = true
+		if _, err := x.ParseSchedule(job.Schedule); err != nil {
+			return nil, fmt.Errorf("serve config %s: job %s: %w", path, job.Name, err)
+		}
+		if len(job.Args) == 0 {
+			return nil, fmt.Errorf("serve config %s: job %s has no args", path, job.Name)
+		}
+		if job.Args[0] == "serve" {
+			return nil, fmt.Errorf("serve config %s: job %s cannot run serve", path, job.Name)
+		}
+		if _, err := job.GetTimeout(); err != nil {
+			return nil, fmt.Errorf("serve config %s: job %s: %w", path, job.Name, err)
+		}
+		if job.Webhook != nil {
+			if job.Webhook.TargetRepo == "" && len(job.Webhook.TargetRepos) == 0 {
+				return nil, fmt.Errorf("serve config %s: job %s: webhook has no target_repo", path, job.Name)
+			}
+			if job.Webhook.SecretEnv == "" {
+				return nil, fmt.Errorf("serve config %s: job %s: webhook has no secret_env", path, job.Name)
+			}
+		}
+	}
+	return &config, nil
+}
+
+// GetTimeout 返回单次执行的超时时间，未设置时返回 0
+func (jc *JobConfig) GetTimeout() (time.Duration, error) {
+	if jc.Timeout == "" {
+		return 0, nil
+	}
+	timeout, err := time.ParseDuration(jc.Timeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timeout %q: %w", jc.Timeout, err)
+	}
+	return timeout, nil
+}
+
+// TargetURLs 返回源仓库对应的所有目标仓库地址，以 "/" 结尾的目标按 rule 计算仓库名称
+func (wc *WebhookConfig) TargetURLs(fullName string, rule *x.RepoNameRule) ([]string, error) {
+	var urls []string
+	for _, target := range append([]string{wc.TargetRepo}, wc.TargetRepos...) {
+		if target == "" {
+			continue
+		}
+		u, err := targetURL(target, fullName, rule)
+		if err != nil {
+			return nil, err
+		}
+		urls = append(urls, u)
+	}
+	return urls, nil
+}
+
+// targetURL 返回源仓库对应的目标仓库地址
+// Examples:
+//
+//	("https://gitea.com/org/", "octo/app", nil)                 -> "https://gitea.com/org/app.git"
+//	("https://gitea.com/org/", "octo/app", {Prefix: "mirror-"}) -> "https://gitea.com/org/mirror-app.git"
+//	("https://gitea.com/org/app.git", "octo/app", nil)          -> "https://gitea.com/org/app.git"
+func targetURL(target, fullName string, rule *x.RepoNameRule) (string, error) {
+	if !strings.HasSuffix(target, "/") {
+		return target, nil
+	}
+	name, err := rule.TargetName(fullName, path.Base(fullName))
+	if err != nil {
+		return "", err
+	}
+	return target + name + ".git", nil
+}
@@ -0,0 +1,228 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"github.com/chihqiang/mpgrm/pkg/x"
+	"slices"
+	"sync"
+	"time"
+)
+
+// maxHistory 为每个任务保留的执行记录数
+const maxHistory = 20
+
+// RunStatus 表示一次任务执行的状态
+type RunStatus string
+
+const (
+	RunStatusRunning  RunStatus = "running"  // 执行中
+	RunStatusSuccess  RunStatus = "success"  // 执行成功
+	RunStatusFailed   RunStatus = "failed"   // 执行失败或超时
+	RunStatusCanceled RunStatus = "canceled" // 被取消或服务停止
+)
+
+// 任务的触发方式
+const (
+	TriggerSchedule = "schedule" // 定时触发
+	TriggerStartup  = "startup"  // 启动时触发
+	TriggerManual   = "manual"   // 手动触发
+	TriggerWebhook  = "webhook"  // Webhook 触发
+)
+
+// Run 记录一次任务执行
+type Run struct {
+	ID         string     `json:"id"`                    // 执行 ID，按开始时间生成
+	Job        string     `json:"job"`                   // 任务名称
+	Trigger    string     `json:"trigger"`               // 触发方式
+	Args       []string   `json:"args"`                  // 实际执行的 mpgrm 参数
+	Status     RunStatus  `json:"status"`                // 执行状态
+	StartedAt  time.Time  `json:"started_at"`            // 开始时间
+	FinishedAt *time.Time `json:"finished_at,omitempty"` // 结束时间
+	Duration   string     `json:"duration,omitempty"`    // 执行耗时
+	Error      string     `json:"error,omitempty"`       // 失败原因
+	LogFile    string     `json:"log_file"`              // 执行日志文件
+	ReportFile string     `json:"report_file"`           // 各仓库结果报告文件
+
+	cancel context.CancelFunc // 取消本次执行
+}
+
+// Job 为运行中的定时任务及其执行记录
+type Job struct {
+	*JobConfig
+	schedule *x.Schedule
+	timeout  time.Duration
+	pushArgs []string        // Webhook 触发的 push 沿用的推送方式参数
+	nameRule *x.RepoNameRule // Webhook 计算目标仓库名称的命名规则
+
+	mu      sync.Mutex
+	current *Run      // 正在执行的记录，未执行时为 nil
+	history []*Run    // 已结束的执行记录，最新的在最后
+	next    time.Time // 下一次定时执行的时间
+	skipped int       // 因上一次尚未结束而跳过的次数
+	queue   []pending // 等待当前执行结束后再执行的请求
+}
+
+// pending 为排队等待的执行请求
+type pending struct {
+	trigger string
+	args    []string
+}
+
+// JobStatus 为任务状态的快照
+type JobStatus struct {
+	Name     string     `json:"name"`
+	Schedule string     `json:"schedule"`
+	Args     []string   `json:"args"`
+	Running  bool       `json:"running"`
+	NextRun  *time.Time `json:"next_run,omitempty"`
+	Skipped  int        `json:"skipped"`
+	Queued   int        `json:"queued"`
+	Current  *Run       `json:"current,omitempty"`
+	LastRun  *Run       `json:"last_run,omitempty"`
+}
+
+// newJob 根据配置创建任务，配置需已通过 LoadConfig 校验
+func newJob(config *JobConfig) (*Job, error) {
+	schedule, err := x.ParseSchedule(config.Schedule)
+	if err != nil {
+		return nil, err
+	}
+	timeout, err := config.GetTimeout()
+	if err != nil {
+		return nil, err
+	}
+	job := &Job{JobConfig: config, schedule: schedule, timeout: timeout}
+	if config.Webhook != nil {
+		if job.pushArgs, job.nameRule, err = webhookOptions(config.Args); err != nil {
+			return nil, fmt.Errorf("webhook: %w", err)
+		}
+	}
+	return job, nil
+}
+
+// Status 返回任务状态的快照，参数和错误中已隐藏地址里的凭据
+func (j *Job) Status() JobStatus {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	status := JobStatus{
+		Name:     j.Name,
+		Schedule: j.Schedule,
+		Args:     redactArgs(j.Args),
+		Running:  j.current != nil,
+		Skipped:  j.skipped,
+		Queued:   len(j.queue),
+	}
+	if !j.next.IsZero() {
+		next := j.next
+		status.NextRun = &next
+	}
+	if j.current != nil {
+		current := j.current.redacted()
+		status.Current = &current
+	}
+	if len(j.history) > 0 {
+		last := j.history[len(j.history)-1].redacted()
+		status.LastRun = &last
+	}
+	return status
+}
+
+// History 返回已结束执行记录的快照，最新的在最前
+func (j *Job) History() []Run {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	runs := make([]Run, 0, len(j.history))
+	for i := len(j.history) - 1; i >= 0; i-- {
+		runs = append(runs, j.history[i].redacted())
+	}
+	return runs
+}
+
+// setNext 记录下一次定时执行的时间
+func (j *Job) setNext(next time.Time) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.next = next
+}
+
+// finish 结束当前执行并写入执行记录
+func (j *Job) finish(run *Run, status RunStatus, errMsg string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	finished := time.Now()
+	run.Status = status
+	run.Error = errMsg
+	run.FinishedAt = &finished
+	run.Duration = finished.Sub(run.StartedAt).Round(time.Millisecond).String()
+	j.current = nil
+	j.history = append(j.history, run)
+	if len(j.history) > maxHistory {
+		j.history = j.history[len(j.history)-maxHistory:]
+	}
+}
+
+// enqueue 在当前执行结束后再执行 args，已在队列中的相同请求只保留一个，调用方需持有锁
+func (j *Job) enqueue(trigger string, args []string) {
+	for _, p := range j.queue {
+		if slices.Equal(p.args, args) {
+			return
+		}
+	}
+	j.queue = append(j.queue, pending{trigger: trigger, args: args})
+}
+
+// dequeue 取出下一个排队的请求，队列为空时返回 false
+func (j *Job) dequeue() (pending, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if len(j.queue) == 0 {
+		return pending{}, false
+	}
+	next := j.queue[0]
+	j.queue = j.queue[1:]
+	return next, true
+}
+
+// Cancel 取消正在执行的记录，没有执行中的记录时返回 false
+func (j *Job) Cancel() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.current == nil {
+		return false
+	}
+	j.current.cancel()
+	return true
+}
+
+// FindRun 按 ID 查找正在执行或已结束的记录，返回的参数和错误中已隐藏地址里的凭据
+func (j *Job) FindRun(id string) (Run, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.current != nil && j.current.ID == id {
+		return j.current.redacted(), true
+	}
+	for _, run := range j.history {
+		if run.ID == id {
+			return run.redacted(), true
+		}
+	}
+	return Run{}, false
+}
+
+// redactArgs 返回隐藏了地址中凭据的参数
+func redactArgs(args []string) []string {
+	redacted := make([]string, len(args))
+	for i, arg := range args {
+		redacted[i] = x.RedactURLs(arg)
+	}
+	return redacted
+}
+
+// redacted 返回隐藏了参数和错误中凭据的执行记录快照
+func (r *Run) redacted() Run {
+	run := *r
+	run.Args = redactArgs(r.Args)
+	run.Error = x.RedactURLs(r.Error)
+	return run
+}
@@ -0,0 +1,292 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/chihqiang/mpgrm/pkg/logx"
+	"github.com/chihqiang/mpgrm/pkg/metrics"
+	"github.com/chihqiang/mpgrm/pkg/report"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+var (
+	ErrJobNotFound = errors.New("job not found")
+	ErrJobRunning  = errors.New("job is already running")
+)
+
+// Options 为执行任务所需的运行参数
+type Options struct {
+	Executable string   // 执行任务的 mpgrm 可执行文件
+	GlobalArgs []string // 传给每个任务的全局参数
+	Workspace  string   // 任务工作区的根目录，每个任务使用其下以任务名命名的子目录，避免并发执行的任务操作同一个克隆
+	LogDir     string   // 任务日志和状态文件目录
+}
+
+// Server 按计划执行同步任务，同一任务不会重叠执行
+type Server struct {
+	config *Config
+	opts   Options
+	jobs   []*Job
+	byName map[string]*Job
+
+	ctx context.Context // 服务的生命周期，任务执行在其结束时被取消
+	wg  sync.WaitGroup  // 等待正在执行的任务
+}
+
+// New 根据配置创建 Server
+func New(config *Config, opts Options) (*Server, error) {
+	s := &Server{config: config, opts: opts, byName: make(map[string]*Job, len(config.Jobs)), ctx: context.Background()}
+	for _, jobConfig := range config.Jobs {
+		job, err := newJob(jobConfig)
+		if err != nil {
+			return nil, fmt.Errorf("job %s: %w", jobConfig.Name, err)
+		}
+		s.jobs = append(s.jobs, job)
+		s.byName[job.Name] = job
+	}
+	return s, nil
+}
+
+// Run 启动所有任务的调度以及 HTTP 接口，阻塞直到 ctx 结束，并等待正在执行的任务退出
+func (s *Server) Run(ctx context.Context) error {
+	s.ctx = ctx
+	if err := os.MkdirAll(s.opts.LogDir, 0755); err != nil {
+		return fmt.Errorf("create log directory: %w", err)
+	}
+	var httpServer *http.Server
+	errCh := make(chan error, 1)
+	if s.config.Listen != "" {
+		httpServer = &http.Server{Addr: s.config.Listen, Handler: s.Handler()}
+		go func() {
+			logx.Info("HTTP server listening on %s", s.config.Listen)
+			if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				errCh <- err
+			}
+		}()
+	}
+	var schedulers sync.WaitGroup
+	for _, job := range s.jobs {
+		schedulers.Add(1)
+		go func(job *Job) {
+			defer schedulers.Done()
+			s.schedule(ctx, job)
+		}(job)
+	}
+	logx.Info("Serving %d jobs", len(s.jobs))
+
+	var err error
+	select {
+	case <-ctx.Done():
+	case err = <-errCh:
+	}
+	if httpServer != nil {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		_ = httpServer.Shutdown(shutdownCtx)
+		cancel()
+	}
+	schedulers.Wait()
+	logx.Info("Waiting for running jobs to stop...")
+	s.wg.Wait()
+	return err
+}
+
+// schedule 按任务的计划循环触发执行，上一次执行尚未结束时跳过本次
+func (s *Server) schedule(ctx context.Context, job *Job) {
+	if job.RunOnStart {
+		if _, err := s.start(job, TriggerStartup, job.Args, false); err != nil {
+			logx.Warn("Skipping startup run of %s: %v", job.Name, err)
+		}
+	}
+	for {
+		next := job.schedule.Next(time.Now())
+		if next.IsZero() {
+			logx.Warn("Job %s has no upcoming run, schedule %q", job.Name, job.Schedule)
+			return
+		}
+		job.setNext(next)
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			if _, err := s.start(job, TriggerSchedule, job.Args, false); err != nil {
+				logx.Warn("Skipping scheduled run of %s: %v", job.Name, err)
+			}
+		}
+	}
+}
+
+// Status 返回所有任务的状态
+func (s *Server) Status() []JobStatus {
+	statuses := make([]JobStatus, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		statuses = append(statuses, job.Status())
+	}
+	return statuses
+}
+
+// start 在后台以 args 开始一次执行并返回执行记录的快照
+// 任务正在执行时，queue 为 true 则排队到当前执行结束后并返回 nil，否则跳过并返回 ErrJobRunning
+func (s *Server) start(job *Job, trigger string, args []string, queue bool) (*Run, error) {
+	job.mu.Lock()
+	defer job.mu.Unlock()
+	if job.current != nil {
+		if queue {
+			job.enqueue(trigger, args)
+			return nil, nil
+		}
+		job.skipped++
+		metrics.JobSkipped.WithLabelValues(job.Name).Inc()
+		return nil, ErrJobRunning
+	}
+	if err := s.ctx.Err(); err != nil {
+		return nil, err
+	}
+	started := time.Now()
+	run := &Run{
+		ID:        started.Format("20060102-150405.000"),
+		Job:       job.Name,
+		Trigger:   trigger,
+		Args:      args,
+		Status:    RunStatusRunning,
+		StartedAt: started,
+	}
+	run.LogFile = filepath.Join(s.opts.LogDir, job.Name, run.ID+".log")
+	run.ReportFile = filepath.Join(s.opts.LogDir, job.Name, run.ID+".json")
+	runCtx, cancel := context.WithCancel(s.ctx)
+	if job.timeout > 0 {
+		// 超时上下文从可取消的上下文派生，cancel 同时释放两者
+		var cancelTimeout context.CancelFunc
+		runCtx, cancelTimeout = context.WithTimeout(runCtx, job.timeout)
+		cancelRun := cancel
+		cancel = func() {
+			cancelTimeout()
+			cancelRun()
+		}
+	}
+	run.cancel = cancel
+	job.current = run
+	metrics.JobRunning.WithLabelValues(job.Name).Set(1)
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer cancel()
+		logx.Info("Job %s started (%s), log: %s", job.Name, trigger, run.LogFile)
+		err := s.execute(runCtx, job, run)
+		var status RunStatus
+		switch {
+		case err == nil:
+			status = RunStatusSuccess
+			job.finish(run, status, "")
+			logx.Info("Job %s succeeded in %s", job.Name, run.Duration)
+		case errors.Is(runCtx.Err(), context.DeadlineExceeded):
+			status = RunStatusFailed
+			job.finish(run, status, fmt.Sprintf("timed out after %s", job.timeout))
+			logx.Warn("Job %s timed out after %s", job.Name, job.timeout)
+		case errors.Is(runCtx.Err(), context.Canceled):
+			status = RunStatusCanceled
+			job.finish(run, status, "canceled")
+			logx.Warn("Job %s canceled after %s", job.Name, run.Duration)
+		default:
+			status = RunStatusFailed
+			job.finish(run, status, err.Error())
+			logx.Warn("Job %s failed after %s: %v", job.Name, run.Duration, err)
+		}
+		s.recordMetrics(job, trigger, status, run)
+		s.writeStatus()
+		if next, ok := job.dequeue(); ok {
+			if _, err := s.start(job, next.trigger, next.args, true); err != nil {
+				logx.Warn("Dropping queued run of %s: %v", job.Name, err)
+			}
+		}
+	}()
+	snapshot := *run
+	return &snapshot, nil
+}
+
+// execute 以子进程执行任务，输出写入本次执行的日志文件
+func (s *Server) execute(ctx context.Context, job *Job, run *Run) error {
+	if err := os.MkdirAll(filepath.Dir(run.LogFile), 0755); err != nil {
+		return err
+	}
+	logFile, err := os.Create(run.LogFile)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = logFile.Close()
+	}()
+	args := append(append([]string{}, s.opts.GlobalArgs...), "--report", run.ReportFile)
+	if s.opts.Workspace != "" {
+		args = append(args, "--workspace", filepath.Join(s.opts.Workspace, job.Name))
+	}
+	args = append(args, run.Args...)
+	cmd := exec.CommandContext(ctx, s.opts.Executable, args...)
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	cmd.Env = os.Environ()
+	for k, v := range job.Env {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
+	cmd.WaitDelay = 10 * time.Second
+	return cmd.Run()
+}
+
+// recordMetrics 记录任务执行的指标，并汇总子进程在报告中写入的同步指标
+func (s *Server) recordMetrics(job *Job, trigger string, status RunStatus, run *Run) {
+	metrics.JobRunning.WithLabelValues(job.Name).Set(0)
+	metrics.JobRuns.WithLabelValues(job.Name, trigger, string(status)).Inc()
+	metrics.JobDuration.WithLabelValues(job.Name).Observe(time.Since(run.StartedAt).Seconds())
+	if status == RunStatusSuccess {
+		metrics.JobLastSuccess.WithLabelValues(job.Name).SetToCurrentTime()
+	}
+	if rep, err := report.Read(run.ReportFile); err == nil {
+		if err := metrics.Merge(rep.Metrics); err != nil {
+			logx.Warn("merge metrics of job %s: %v", job.Name, err)
+		}
+	}
+}
+
+// writeStatus 将所有任务的状态写入日志目录下的 status.json，便于外部监控
+func (s *Server) writeStatus() {
+	data, err := json.MarshalIndent(s.Status(), "", "  ")
+	if err != nil {
+		return
+	}
+	if err := os.WriteFile(filepath.Join(s.opts.LogDir, "status.json"), data, 0644); err != nil {
+		logx.Warn("write status file: %v", err)
+	}
+}
+
+// Handler 返回 HTTP 接口
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	// 配置了 API 令牌时状态同样需要认证，参数和错误中的凭据始终隐藏
+	status := func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, s.Status())
+	}
+	if s.config.APITokenEnv != "" {
+		status = s.authorize(status)
+	}
+	mux.HandleFunc("GET /status", status)
+	mux.HandleFunc("POST /webhook", s.handleWebhook)
+	mux.Handle("GET /metrics", promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{}))
+	s.registerAPI(mux)
+	return mux
+}
+
+// writeJSON 以 JSON 格式写入响应
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,384 @@
+package server
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/chihqiang/mpgrm/pkg/logx"
+	"github.com/chihqiang/mpgrm/pkg/metrics"
+	"github.com/chihqiang/mpgrm/pkg/x"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxWebhookBody 限制 Webhook 请求体大小
+const maxWebhookBody = 25 << 20
+
+// giteeTimestampTolerance 为 Gitee 签名模式下请求时间戳与当前时间允许的最大偏差
+// Gitee 的签名不包含请求体，只能通过拒绝过期的时间戳限制签名被重放
+const giteeTimestampTolerance = 5 * time.Minute
+
+// 发送 Webhook 的平台
+const (
+	webhookGitHub = "github"
+	webhookGitea  = "gitea"
+	webhookGitee  = "gitee"
+	webhookCNB    = "cnb"
+)
+
+// 统一的 Webhook 事件
+const (
+	webhookEventPush    = "push"
+	webhookEventTagPush = "tag_push"
+	webhookEventRelease = "release"
+)
+
+// webhookEvent 为解析后的 Webhook 事件
+type webhookEvent struct {
+	Platform string
+	Event    string // 统一的事件名称，不支持的事件保留平台原始名称
+	Action   string // Release 事件的动作，例如 published、deleted
+	FullName string // 源仓库完整名称
+	CloneURL string // 源仓库克隆地址
+	Ref      string // 分支或标签名称
+	Deleted  bool   // 分支或标签被删除
+}
+
+// webhookPayload 为 GitHub、Gitea 和 Gitee 推送及 Release 事件中用到的字段
+type webhookPayload struct {
+	Ref     string `json:"ref"`
+	After   string `json:"after"`
+	Deleted bool   `json:"deleted"`
+	Action  string `json:"action"`
+	Release struct {
+		TagName string `json:"tag_name"`
+	} `json:"release"`
+	Repository struct {
+		FullName   string `json:"full_name"`
+		CloneURL   string `json:"clone_url"`
+		GitHTTPURL string `json:"git_http_url"`
+		HTMLURL    string `json:"html_url"`
+	} `json:"repository"`
+}
+
+// cnbPayload 为 CNB 流水线回调的请求体，字段取自流水线的内置环境变量
+type cnbPayload struct {
+	Event    string `json:"event"`     // $CNB_EVENT
+	RepoSlug string `json:"repo_slug"` // $CNB_REPO_SLUG
+	RepoURL  string `json:"repo_url"`  // $CNB_REPO_URL_HTTPS
+	Ref      string `json:"ref"`       // $CNB_BRANCH
+}
+
+// handleWebhook 接收源平台的 Webhook，校验签名后为对应仓库执行一次增量同步
+func (s *Server) handleWebhook(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBody))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+	event, err := parseWebhook(r.Header, body)
+	if err != nil {
+		metrics.Webhooks.WithLabelValues("unknown", "invalid").Inc()
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+	var matched bool
+	var job *Job
+	for _, candidate := range s.jobs {
+		if candidate.Webhook == nil || !x.RepoMatch(event.FullName, candidate.Webhook.Repos) {
+			continue
+		}
+		matched = true
+		secret := os.Getenv(candidate.Webhook.SecretEnv)
+		if secret != "" && verifyWebhook(event.Platform, r.Header, body, secret) {
+			job = candidate
+			break
+		}
+	}
+	if !matched {
+		metrics.Webhooks.WithLabelValues(event.Platform, "unmatched").Inc()
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": fmt.Sprintf("no job for repository %s", event.FullName)})
+		return
+	}
+	if job == nil {
+		logx.Warn("Rejected %s webhook for %s: invalid signature", event.Platform, event.FullName)
+		metrics.Webhooks.WithLabelValues(event.Platform, "rejected").Inc()
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid signature"})
+		return
+	}
+	targetURLs, err := job.Webhook.TargetURLs(event.FullName, job.nameRule)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+	args := event.args(targetURLs, job.pushArgs)
+	if args == nil {
+		metrics.Webhooks.WithLabelValues(event.Platform, "ignored").Inc()
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ignored", "event": event.Event})
+		return
+	}
+	logx.Info("Received %s %s webhook for %s, running job %s", event.Platform, event.Event, event.FullName, job.Name)
+	metrics.Webhooks.WithLabelValues(event.Platform, "accepted").Inc()
+	run, err := s.start(job, TriggerWebhook, args, true)
+	if err != nil {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": err.Error()})
+		return
+	}
+	if run == nil {
+		writeJSON(w, http.StatusAccepted, map[string]string{"status": "queued", "job": job.Name})
+		return
+	}
+	writeJSON(w, http.StatusAccepted, run)
+}
+
+// args 返回同步该事件所需的 mpgrm 参数，pushArgs 为沿用任务的推送方式参数，不需要同步的事件返回 nil
+func (e *webhookEvent) args(targetURLs, pushArgs []string) []string {
+	if e.Deleted || e.Ref == "" {
+		return nil
+	}
+	var targets []string
+	for _, targetURL := range targetURLs {
+		targets = append(targets, "--target-repo", targetURL)
+	}
+	switch e.Event {
+	case webhookEventPush:
+		return slices.Concat([]string{"push", "--repo", e.CloneURL}, targets, pushArgs, []string{"--branches", e.Ref})
+	case webhookEventTagPush:
+		return slices.Concat([]string{"push", "--repo", e.CloneURL}, targets, pushArgs, []string{"--tags", e.Ref})
+	case webhookEventRelease:
+		if e.Action == "deleted" || e.Action == "unpublished" {
+			return nil
+		}
+		return slices.Concat([]string{"releases", "sync", "--repo", e.CloneURL}, targets, []string{"--tags", e.Ref})
+	}
+	return nil
+}
+
+// webhookFlags 为 Webhook 触发的执行需要沿用的任务参数，值表示参数是否带值
+var webhookFlags = map[string]bool{
+	"no-force":           false,
+	"force-with-lease":   false,
+	"atomic":             false,
+	"protected-branches": true,
+	"push-option":        true,
+	"o":                  true,
+	"name-template":      true,
+	"name-prefix":        true,
+	"name-suffix":        true,
+	"name-lower":         false,
+	"name-map":           true,
+}
+
+// webhookOptions 从任务参数中取出 Webhook 触发的 push 沿用的推送方式参数，以及计算目标仓库名称的命名规则
+// Examples:
+//
+//	["repo", "sync", "--no-force", "-o", "ci.skip", "--name-prefix=mirror-"] -> ["--no-force", "--push-option", "ci.skip"], {Prefix: "mirror-"}
+func webhookOptions(args []string) ([]string, *x.RepoNameRule, error) {
+	values := make(map[string][]string)
+	for i := 0; i < len(args); i++ {
+		if args[i] == "--" {
+			break
+		}
+		if !strings.HasPrefix(args[i], "-") {
+			continue
+		}
+		name, value, hasValue := strings.Cut(strings.TrimLeft(args[i], "-"), "=")
+		takesValue, ok := webhookFlags[name]
+		if !ok {
+			continue
+		}
+		switch {
+		case hasValue:
+		case !takesValue:
+			value = "true"
+		case i+1 < len(args):
+			i++
+			value = args[i]
+		default:
+			return nil, nil, fmt.Errorf("flag %s needs a value", args[i])
+		}
+		if name == "o" {
+			name = "push-option"
+		}
+		values[name] = append(values[name], value)
+	}
+	last := func(name string) string {
+		if len(values[name]) == 0 {
+			return ""
+		}
+		return values[name][len(values[name])-1]
+	}
+	enabled := func(name string) (bool, error) {
+		if last(name) == "" {
+			return false, nil
+		}
+		ok, err := strconv.ParseBool(last(name))
+		if err != nil {
+			return false, fmt.Errorf("invalid value %q for flag --%s", last(name), name)
+		}
+		return ok, nil
+	}
+
+	var pushArgs []string
+	for _, name := range []string{"no-force", "force-with-lease", "atomic"} {
+		ok, err := enabled(name)
+		if err != nil {
+			return nil, nil, err
+		}
+		if ok {
+			pushArgs = append(pushArgs, "--"+name)
+		}
+	}
+	for _, name := range []string{"protected-branches", "push-option"} {
+		for _, value := range values[name] {
+			pushArgs = append(pushArgs, "--"+name, value)
+		}
+	}
+
+	renames, err := x.ParseRepoRenames(x.StringSplitUniq(values["name-map"], ","))
+	if err != nil {
+		return nil, nil, err
+	}
+	lower, err := enabled("name-lower")
+	if err != nil {
+		return nil, nil, err
+	}
+	rule := &x.RepoNameRule{
+		Template: last("name-template"),
+		Prefix:   last("name-prefix"),
+		Suffix:   last("name-suffix"),
+		Lower:    lower,
+		Rename:   renames,
+	}
+	return pushArgs, rule, rule.Validate()
+}
+
+// parseWebhook 根据请求头识别平台并解析事件
+func parseWebhook(header http.Header, body []byte) (*webhookEvent, error) {
+	event := &webhookEvent{}
+	var name string
+	switch {
+	// Gitea 同时发送 X-GitHub-Event，需先于 GitHub 判断
+	case header.Get("X-Gitea-Event") != "":
+		event.Platform, name = webhookGitea, header.Get("X-Gitea-Event")
+	case header.Get("X-Forgejo-Event") != "":
+		event.Platform, name = webhookGitea, header.Get("X-Forgejo-Event")
+	case header.Get("X-Gitee-Event") != "":
+		event.Platform, name = webhookGitee, header.Get("X-Gitee-Event")
+	case header.Get("X-GitHub-Event") != "":
+		event.Platform, name = webhookGitHub, header.Get("X-GitHub-Event")
+	default:
+		return parseCNBWebhook(body)
+	}
+	var payload webhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("parse %s webhook: %w", event.Platform, err)
+	}
+	event.FullName = payload.Repository.FullName
+	event.CloneURL = payload.Repository.CloneURL
+	if event.CloneURL == "" {
+		event.CloneURL = payload.Repository.GitHTTPURL
+	}
+	if event.CloneURL == "" && payload.Repository.HTMLURL != "" {
+		event.CloneURL = payload.Repository.HTMLURL + ".git"
+	}
+	if event.FullName == "" || event.CloneURL == "" {
+		return nil, errors.New("webhook payload has no repository")
+	}
+	switch name {
+	case "push", "Push Hook", "Tag Push Hook":
+		event.Event, event.Ref = refEvent(payload.Ref)
+		event.Deleted = payload.Deleted || (payload.After != "" && strings.Trim(payload.After, "0") == "")
+	case "release":
+		event.Event = webhookEventRelease
+		event.Action = payload.Action
+		event.Ref = payload.Release.TagName
+	default:
+		event.Event = name
+	}
+	return event, nil
+}
+
+// parseCNBWebhook 解析 CNB 流水线的回调，CNB 没有仓库级 Webhook，需在流水线中主动请求
+func parseCNBWebhook(body []byte) (*webhookEvent, error) {
+	var payload cnbPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("parse cnb webhook: %w", err)
+	}
+	if payload.RepoSlug == "" || payload.RepoURL == "" {
+		return nil, errors.New("unknown webhook: missing platform event header")
+	}
+	event := &webhookEvent{Platform: webhookCNB, Event: payload.Event, FullName: payload.RepoSlug, CloneURL: payload.RepoURL}
+	switch payload.Event {
+	case webhookEventPush, webhookEventTagPush:
+		_, event.Ref = refEvent(payload.Ref)
+	}
+	return event, nil
+}
+
+// refEvent 根据完整引用名称返回事件和分支或标签名称
+func refEvent(ref string) (string, string) {
+	if tag, ok := strings.CutPrefix(ref, "refs/tags/"); ok {
+		return webhookEventTagPush, tag
+	}
+	return webhookEventPush, strings.TrimPrefix(ref, "refs/heads/")
+}
+
+// verifyWebhook 按平台的签名方式校验请求
+func verifyWebhook(platform string, header http.Header, body []byte, secret string) bool {
+	switch platform {
+	case webhookGitHub:
+		return verifyHexHMAC(strings.TrimPrefix(header.Get("X-Hub-Signature-256"), "sha256="), body, secret)
+	case webhookGitea:
+		signature := header.Get("X-Gitea-Signature")
+		if signature == "" {
+			signature = header.Get("X-Forgejo-Signature")
+		}
+		return verifyHexHMAC(signature, body, secret)
+	case webhookGitee:
+		// 签名模式：base64(HMAC-SHA256(secret, timestamp + "\n" + secret))；密码模式：直接发送密码
+		token := header.Get("X-Gitee-Token")
+		if timestamp := header.Get("X-Gitee-Timestamp"); timestamp != "" {
+			mac := hmac.New(sha256.New, []byte(secret))
+			mac.Write([]byte(timestamp + "\n" + secret))
+			if hmac.Equal([]byte(token), []byte(base64.StdEncoding.EncodeToString(mac.Sum(nil)))) {
+				return freshGiteeTimestamp(timestamp, time.Now())
+			}
+		}
+		return subtle.ConstantTimeCompare([]byte(token), []byte(secret)) == 1
+	case webhookCNB:
+		return subtle.ConstantTimeCompare([]byte(header.Get("Authorization")), []byte("Bearer "+secret)) == 1
+	}
+	return false
+}
+
+// freshGiteeTimestamp 判断 Gitee 的毫秒时间戳与 now 的偏差是否在允许范围内
+func freshGiteeTimestamp(timestamp string, now time.Time) bool {
+	millis, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+	diff := now.Sub(time.UnixMilli(millis))
+	return diff.Abs() <= giteeTimestampTolerance
+}
+
+// verifyHexHMAC 校验十六进制编码的 HMAC-SHA256 签名
+func verifyHexHMAC(signature string, body []byte, secret string) bool {
+	expected, err := hex.DecodeString(signature)
+	if err != nil || len(expected) == 0 {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(expected, mac.Sum(nil))
+}
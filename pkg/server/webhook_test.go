@@ -0,0 +1,346 @@
+package server
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/chihqiang/mpgrm/pkg/x"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+const testWebhookSecret = "s3cr3t"
+
+// newWebhookServer 返回一个匹配 octo/* 的任务，args 为追加到任务参数后的参数，任务由什么都不做的 true 命令执行
+func newWebhookServer(t *testing.T, args ...string) *Server {
+	t.Helper()
+	executable, err := exec.LookPath("true")
+	if err != nil {
+		t.Skip("true command not found")
+	}
+	t.Setenv("TEST_WEBHOOK_SECRET", testWebhookSecret)
+	s, err := New(&Config{Jobs: []*JobConfig{{
+		Name:     "mirror",
+		Schedule: "@daily",
+		Args:     append([]string{"repo", "sync"}, args...),
+		Webhook: &WebhookConfig{
+			Repos:      []string{"octo/*"},
+			TargetRepo: "https://gitea.example.com/mirror/",
+			SecretEnv:  "TEST_WEBHOOK_SECRET",
+		},
+	}}}, Options{Executable: executable, LogDir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	t.Cleanup(s.wg.Wait)
+	return s
+}
+
+func hexSignature(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func giteeSignature(timestamp, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "\n" + secret))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func giteeTimestamp(t time.Time) string {
+	return strconv.FormatInt(t.UnixMilli(), 10)
+}
+
+func TestHandleWebhook(t *testing.T) {
+	now := time.Now()
+	fresh, stale := giteeTimestamp(now), giteeTimestamp(now.Add(-time.Hour))
+	target := "https://gitea.example.com/mirror/app.git"
+	tests := []struct {
+		name     string
+		file     string
+		header   func(body []byte) map[string]string
+		wantCode int
+		wantArgs []string
+	}{
+		{
+			name: "github push",
+			file: "github_push.json",
+			header: func(body []byte) map[string]string {
+				return map[string]string{"X-GitHub-Event": "push", "X-Hub-Signature-256": "sha256=" + hexSignature(body, testWebhookSecret)}
+			},
+			wantCode: http.StatusAccepted,
+			wantArgs: []string{"push", "--repo", "https://github.com/octo/app.git", "--target-repo", target, "--branches", "main"},
+		},
+		{
+			name: "github bad signature",
+			file: "github_push.json",
+			header: func(body []byte) map[string]string {
+				return map[string]string{"X-GitHub-Event": "push", "X-Hub-Signature-256": "sha256=" + hexSignature(body, "wrong")}
+			},
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name: "github missing signature",
+			file: "github_push.json",
+			header: func(body []byte) map[string]string {
+				return map[string]string{"X-GitHub-Event": "push"}
+			},
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name: "github branch deleted",
+			file: "github_delete.json",
+			header: func(body []byte) map[string]string {
+				return map[string]string{"X-GitHub-Event": "push", "X-Hub-Signature-256": "sha256=" + hexSignature(body, testWebhookSecret)}
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "github release",
+			file: "github_release.json",
+			header: func(body []byte) map[string]string {
+				return map[string]string{"X-GitHub-Event": "release", "X-Hub-Signature-256": "sha256=" + hexSignature(body, testWebhookSecret)}
+			},
+			wantCode: http.StatusAccepted,
+			wantArgs: []string{"releases", "sync", "--repo", "https://github.com/octo/app.git", "--target-repo", target, "--tags", "v1.2.0"},
+		},
+		{
+			name: "gitea tag push",
+			file: "gitea_tag_push.json",
+			header: func(body []byte) map[string]string {
+				return map[string]string{"X-Gitea-Event": "push", "X-GitHub-Event": "push", "X-Gitea-Signature": hexSignature(body, testWebhookSecret)}
+			},
+			wantCode: http.StatusAccepted,
+			wantArgs: []string{"push", "--repo", "https://gitea.example.com/octo/app.git", "--target-repo", target, "--tags", "v1.2.0"},
+		},
+		{
+			name: "gitea bad signature",
+			file: "gitea_tag_push.json",
+			header: func(body []byte) map[string]string {
+				return map[string]string{"X-Gitea-Event": "push", "X-Gitea-Signature": hexSignature(append(body, ' '), testWebhookSecret)}
+			},
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name: "gitee signed push",
+			file: "gitee_push.json",
+			header: func(body []byte) map[string]string {
+				return map[string]string{"X-Gitee-Event": "Push Hook", "X-Gitee-Timestamp": fresh, "X-Gitee-Token": giteeSignature(fresh, testWebhookSecret)}
+			},
+			wantCode: http.StatusAccepted,
+			wantArgs: []string{"push", "--repo", "https://gitee.com/octo/app.git", "--target-repo", target, "--branches", "master"},
+		},
+		{
+			name: "gitee stale timestamp",
+			file: "gitee_push.json",
+			header: func(body []byte) map[string]string {
+				return map[string]string{"X-Gitee-Event": "Push Hook", "X-Gitee-Timestamp": stale, "X-Gitee-Token": giteeSignature(stale, testWebhookSecret)}
+			},
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name: "gitee bad signature",
+			file: "gitee_push.json",
+			header: func(body []byte) map[string]string {
+				return map[string]string{"X-Gitee-Event": "Push Hook", "X-Gitee-Timestamp": fresh, "X-Gitee-Token": giteeSignature(fresh, "wrong")}
+			},
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name: "gitee password tag push",
+			file: "gitee_tag_push.json",
+			header: func(body []byte) map[string]string {
+				return map[string]string{"X-Gitee-Event": "Tag Push Hook", "X-Gitee-Token": testWebhookSecret}
+			},
+			wantCode: http.StatusAccepted,
+			wantArgs: []string{"push", "--repo", "https://gitee.com/octo/app.git", "--target-repo", target, "--tags", "v1.2.0"},
+		},
+		{
+			name: "gitee branch deleted",
+			file: "gitee_delete.json",
+			header: func(body []byte) map[string]string {
+				return map[string]string{"X-Gitee-Event": "Push Hook", "X-Gitee-Token": testWebhookSecret}
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "cnb push",
+			file: "cnb_push.json",
+			header: func(body []byte) map[string]string {
+				return map[string]string{"Authorization": "Bearer " + testWebhookSecret}
+			},
+			wantCode: http.StatusAccepted,
+			wantArgs: []string{"push", "--repo", "https://cnb.cool/octo/app", "--target-repo", target, "--branches", "main"},
+		},
+		{
+			name: "cnb bad token",
+			file: "cnb_push.json",
+			header: func(body []byte) map[string]string {
+				return map[string]string{"Authorization": "Bearer wrong"}
+			},
+			wantCode: http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := os.ReadFile(filepath.Join("testdata", tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body))
+			for k, v := range tt.header(body) {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			newWebhookServer(t).Handler().ServeHTTP(rec, req)
+			if rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d, body: %s", rec.Code, tt.wantCode, rec.Body)
+			}
+			if tt.wantArgs == nil {
+				return
+			}
+			var run Run
+			if err := json.Unmarshal(rec.Body.Bytes(), &run); err != nil {
+				t.Fatalf("decode run: %v", err)
+			}
+			if !reflect.DeepEqual(run.Args, tt.wantArgs) {
+				t.Errorf("args = %q, want %q", run.Args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestHandleWebhookUnmatched(t *testing.T) {
+	body := []byte(`{"ref": "refs/heads/main", "repository": {"full_name": "other/app", "clone_url": "https://github.com/other/app.git"}}`)
+	req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body))
+	req.Header.Set("X-GitHub-Event", "push")
+	req.Header.Set("X-Hub-Signature-256", "sha256="+hexSignature(body, testWebhookSecret))
+	rec := httptest.NewRecorder()
+	newWebhookServer(t).Handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+func TestHandleWebhookJobFlags(t *testing.T) {
+	body, err := os.ReadFile(filepath.Join("testdata", "github_push.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body))
+	req.Header.Set("X-GitHub-Event", "push")
+	req.Header.Set("X-Hub-Signature-256", "sha256="+hexSignature(body, testWebhookSecret))
+	rec := httptest.NewRecorder()
+	s := newWebhookServer(t, "--no-force", "--protected-branches", "release/*", "-o", "ci.skip", "--name-prefix=mirror-", "--name-lower")
+	s.Handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("status = %d, want %d, body: %s", rec.Code, http.StatusAccepted, rec.Body)
+	}
+	var run Run
+	if err := json.Unmarshal(rec.Body.Bytes(), &run); err != nil {
+		t.Fatalf("decode run: %v", err)
+	}
+	want := []string{"push", "--repo", "https://github.com/octo/app.git", "--target-repo", "https://gitea.example.com/mirror/mirror-app.git",
+		"--no-force", "--protected-branches", "release/*", "--push-option", "ci.skip", "--branches", "main"}
+	if !reflect.DeepEqual(run.Args, want) {
+		t.Errorf("args = %q, want %q", run.Args, want)
+	}
+}
+
+func TestWebhookOptions(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         []string
+		wantPushArgs []string
+		wantRule     *x.RepoNameRule
+		wantErr      bool
+	}{
+		{
+			name:     "no flags",
+			args:     []string{"repo", "sync", "--repo", "https://github.com/org/"},
+			wantRule: &x.RepoNameRule{Rename: map[string]string{}},
+		},
+		{
+			name:         "push mode",
+			args:         []string{"repo", "sync", "--force-with-lease", "--atomic=false", "--push-option=a=1", "-o", "b=2", "--protected-branches", "main,release/*"},
+			wantPushArgs: []string{"--force-with-lease", "--protected-branches", "main,release/*", "--push-option", "a=1", "--push-option", "b=2"},
+			wantRule:     &x.RepoNameRule{Rename: map[string]string{}},
+		},
+		{
+			name:     "naming",
+			args:     []string{"repo", "sync", "--name-template", "{{.Owner}}-{{.Name}}", "--name-suffix=-mirror", "--name-map", "api=platform-api"},
+			wantRule: &x.RepoNameRule{Template: "{{.Owner}}-{{.Name}}", Suffix: "-mirror", Rename: map[string]string{"api": "platform-api"}},
+		},
+		{
+			name:     "after terminator",
+			args:     []string{"repo", "sync", "--", "--no-force"},
+			wantRule: &x.RepoNameRule{Rename: map[string]string{}},
+		},
+		{
+			name:    "missing value",
+			args:    []string{"repo", "sync", "--name-prefix"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid bool",
+			args:    []string{"repo", "sync", "--no-force=maybe"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid name map",
+			args:    []string{"repo", "sync", "--name-map", "api"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pushArgs, rule, err := webhookOptions(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("webhookOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(pushArgs, tt.wantPushArgs) {
+				t.Errorf("pushArgs = %q, want %q", pushArgs, tt.wantPushArgs)
+			}
+			if !reflect.DeepEqual(rule, tt.wantRule) {
+				t.Errorf("rule = %+v, want %+v", rule, tt.wantRule)
+			}
+		})
+	}
+}
+
+func TestFreshGiteeTimestamp(t *testing.T) {
+	now := time.UnixMilli(1760832764000)
+	tests := []struct {
+		name      string
+		timestamp string
+		want      bool
+	}{
+		{"now", giteeTimestamp(now), true},
+		{"slightly old", giteeTimestamp(now.Add(-4 * time.Minute)), true},
+		{"slightly ahead", giteeTimestamp(now.Add(time.Minute)), true},
+		{"stale", giteeTimestamp(now.Add(-6 * time.Minute)), false},
+		{"far future", giteeTimestamp(now.Add(time.Hour)), false},
+		{"not a number", "yesterday", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := freshGiteeTimestamp(tt.timestamp, now); got != tt.want {
+				t.Errorf("freshGiteeTimestamp(%q) = %v, want %v", tt.timestamp, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package x
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSchedule(t *testing.T) {
+	tests := []struct {
+		spec    string
+		wantErr bool
+	}{
+		{"*/15 * * * *", false},
+		{"0 2 * * mon-fri", false},
+		{"30 4 1,15 jan-jun 0", false},
+		{"@daily", false},
+		{"@every 90m", false},
+		{"@every 10s", true},
+		{"* * * *", true},
+		{"60 * * * *", true},
+		{"5-1 * * * *", true},
+		{"*/0 * * * *", true},
+		{"* * * * funday", true},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseSchedule(tt.spec)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseSchedule(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+		}
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	// 2024-03-15 是周五
+	from := time.Date(2024, 3, 15, 10, 7, 30, 0, time.UTC)
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"*/15 * * * *", time.Date(2024, 3, 15, 10, 15, 0, 0, time.UTC)},
+		{"0 * * * *", time.Date(2024, 3, 15, 11, 0, 0, 0, time.UTC)},
+		{"0 2 * * *", time.Date(2024, 3, 16, 2, 0, 0, 0, time.UTC)},
+		{"0 2 * * mon-fri", time.Date(2024, 3, 18, 2, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 13 * 5", time.Date(2024, 3, 22, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2024, 3, 17, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"@every 90m", from.Add(90 * time.Minute)},
+	}
+
+	for _, tt := range tests {
+		s, err := ParseSchedule(tt.spec)
+		if err != nil {
+			t.Fatalf("ParseSchedule(%q) error = %v", tt.spec, err)
+		}
+		if got := s.Next(from); !got.Equal(tt.want) {
+			t.Errorf("ParseSchedule(%q).Next() = %v; want %v", tt.spec, got, tt.want)
+		}
+	}
+}
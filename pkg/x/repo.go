@@ -3,6 +3,7 @@ package x
 import (
 	"fmt"
 	"net/url"
+	"path"
 	"strings"
 )
 
@@ -124,3 +125,60 @@ func RepoFullNameOrgName(p string) string {
 	}
 	return strings.Join(cleanParts, "/")
 }
+
+// RepoSubPath returns the organization path of fullName relative to rootOrg.
+// Examples:
+//
+//	("org", "org/repo")              -> ""
+//	("org", "org/child/repo")        -> "child"
+//	("org", "org/child/child2/repo") -> "child/child2"
+//	("", "org/repo")                 -> ""
+func RepoSubPath(rootOrg, fullName string) string {
+	org := RepoFullNameOrgName(fullName)
+	rootOrg = strings.Trim(rootOrg, "/")
+	if rootOrg == "" || org == rootOrg || !strings.HasPrefix(org, rootOrg+"/") {
+		return ""
+	}
+	return strings.TrimPrefix(org, rootOrg+"/")
+}
+
+// RepoWikiURL returns the clone URL of the wiki repository that belongs to cloneURL.
+// Examples:
+//
+//	"https://github.com/org/repo.git" -> "https://github.com/org/repo.wiki.git"
+//	"https://gitee.com/org/repo"      -> "https://gitee.com/org/repo.wiki.git"
+func RepoWikiURL(cloneURL string) (string, error) {
+	base := strings.TrimSuffix(strings.TrimSuffix(cloneURL, "/"), ".git")
+	if strings.HasSuffix(base, ".wiki") {
+		return "", fmt.Errorf("%s is already a wiki repository", cloneURL)
+	}
+	if _, _, err := RepoParseFullName(base); err != nil {
+		return "", fmt.Errorf("invalid repository URL %s: %w", cloneURL, err)
+	}
+	return base + ".wiki.git", nil
+}
+
+// RepoMatch reports whether fullName matches any of the glob patterns.
+// A pattern containing "/" is matched against the full name, otherwise against the repository name.
+// An empty pattern list matches every repository.
+// Examples:
+//
+//	("org/api-server", ["api-*"])     -> true
+//	("org/api-server", ["org/*"])     -> true
+//	("org/web", ["api-*", "other/*"]) -> false
+func RepoMatch(fullName string, patterns []string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	name := path.Base(fullName)
+	for _, pattern := range patterns {
+		subject := name
+		if strings.Contains(pattern, "/") {
+			subject = fullName
+		}
+		if ok, _ := path.Match(pattern, subject); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+package x
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// RepoNameData 是目标仓库命名模板可用的变量
+type RepoNameData struct {
+	Owner    string // 源仓库的组织/所有者，例如: "my-org" 或 "org/child"
+	Name     string // 源仓库短名，例如: "my-repo"
+	FullName string // 源仓库全名，例如: "my-org/my-repo"
+}
+
+// RepoNameRule 描述如何由源仓库名推导目标仓库名
+// 处理顺序: Rename 映射 > Template > Prefix/Suffix > Lower
+type RepoNameRule struct {
+	Template string            // Go text/template，例如: "{{.Owner}}-{{.Name}}"
+	Prefix   string            // 目标仓库名前缀
+	Suffix   string            // 目标仓库名后缀
+	Lower    bool              // 是否转换为小写
+	Rename   map[string]string // 显式重命名映射，key 可以是源仓库短名或全名
+}
+
+// ParseRepoRenames 解析 "source=target" 形式的重命名映射
+// Examples:
+//
+//	["api=platform-api", "org/web=portal"] -> {"api": "platform-api", "org/web": "portal"}
+func ParseRepoRenames(pairs []string) (map[string]string, error) {
+	renames := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		source, target, ok := strings.Cut(pair, "=")
+		source = strings.TrimSpace(source)
+		target = strings.TrimSpace(target)
+		if !ok || source == "" || target == "" {
+			return nil, fmt.Errorf("invalid rename %q, expected source=target", pair)
+		}
+		renames[source] = target
+	}
+	return renames, nil
+}
+
+// IsZero 判断规则是否为空（即目标名与源仓库短名一致）
+func (r *RepoNameRule) IsZero() bool {
+	return r == nil || (r.Template == "" && r.Prefix == "" && r.Suffix == "" && !r.Lower && len(r.Rename) == 0)
+}
+
+// Validate 检查模板语法是否正确
+func (r *RepoNameRule) Validate() error {
+	if r == nil || r.Template == "" {
+		return nil
+	}
+	_, err := template.New("name").Option("missingkey=error").Parse(r.Template)
+	if err != nil {
+		return fmt.Errorf("invalid name template %q: %w", r.Template, err)
+	}
+	return nil
+}
+
+// TargetName 根据规则计算目标仓库名
+// fullName 为源仓库全名，name 为源仓库短名（为空时从 fullName 中解析）
+func (r *RepoNameRule) TargetName(fullName, name string) (string, error) {
+	data := RepoNameData{
+		Owner:    RepoFullNameOrgName(fullName),
+		Name:     name,
+		FullName: fullName,
+	}
+	if data.Name == "" {
+		if _, repo, err := RepoParseFullName(fullName); err == nil {
+			data.Name = repo
+		}
+	}
+	if data.Name == "" {
+		return "", fmt.Errorf("cannot resolve repository name from %q", fullName)
+	}
+	if r.IsZero() {
+		return data.Name, nil
+	}
+	if target, ok := r.Rename[fullName]; ok {
+		return target, nil
+	}
+	if target, ok := r.Rename[data.Name]; ok {
+		return target, nil
+	}
+	target := data.Name
+	if r.Template != "" {
+		tpl, err := template.New("name").Option("missingkey=error").Parse(r.Template)
+		if err != nil {
+			return "", fmt.Errorf("invalid name template %q: %w", r.Template, err)
+		}
+		var buf bytes.Buffer
+		if err := tpl.Execute(&buf, data); err != nil {
+			return "", fmt.Errorf("render name template for %q: %w", fullName, err)
+		}
+		target = buf.String()
+	}
+	target = r.Prefix + target + r.Suffix
+	if r.Lower {
+		target = strings.ToLower(target)
+	}
+	// 组织路径中的斜杠不能出现在仓库名里
+	target = strings.ReplaceAll(strings.TrimSpace(target), "/", "-")
+	if target == "" {
+		return "", fmt.Errorf("empty target name for %q", fullName)
+	}
+	return target, nil
+}
+
+// ownerFieldRegexp 匹配模板中引用组织路径的字段
+var ownerFieldRegexp = regexp.MustCompile(`\.(Owner|FullName)\b`)
+
+// UsesOwner 判断 fullName 的目标名是否已包含源仓库的组织路径，即未被 Rename 映射且模板引用了 Owner 或 FullName
+// 此时扁平化子组织时不需要再添加子组织路径前缀
+func (r *RepoNameRule) UsesOwner(fullName, name string) bool {
+	if r == nil || !ownerFieldRegexp.MatchString(r.Template) {
+		return false
+	}
+	if name == "" {
+		_, name, _ = RepoParseFullName(fullName)
+	}
+	if _, ok := r.Rename[fullName]; ok {
+		return false
+	}
+	_, ok := r.Rename[name]
+	return !ok
+}
+
+// RepoNameCollisions 返回多个源仓库映射到同一目标名的冲突
+// 返回值的 key 为目标名，value 为映射到该目标名的源仓库全名（已排序）
+func RepoNameCollisions(targets map[string]string) map[string][]string {
+	sources := make(map[string][]string)
+	for source, target := range targets {
+		key := strings.ToLower(target)
+		sources[key] = append(sources[key], source)
+	}
+	collisions := make(map[string][]string)
+	for target, names := range sources {
+		if len(names) > 1 {
+			sort.Strings(names)
+			collisions[target] = names
+		}
+	}
+	return collisions
+}
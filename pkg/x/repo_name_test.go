@@ -0,0 +1,90 @@
+package x
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRepoNameRuleTargetName(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     *RepoNameRule
+		fullName string
+		want     string
+		wantErr  bool
+	}{
+		{"nil rule", nil, "org/repo", "repo", false},
+		{"empty rule", &RepoNameRule{}, "org/repo", "repo", false},
+		{"owner template", &RepoNameRule{Template: "{{.Owner}}-{{.Name}}"}, "org/repo", "org-repo", false},
+		{"nested owner", &RepoNameRule{Template: "{{.Owner}}-{{.Name}}"}, "org/child/repo", "org-child-repo", false},
+		{"prefix suffix", &RepoNameRule{Prefix: "gh-", Suffix: "-mirror"}, "org/repo", "gh-repo-mirror", false},
+		{"lower", &RepoNameRule{Template: "{{.Owner}}_{{.Name}}", Lower: true}, "MyOrg/Repo", "myorg_repo", false},
+		{"rename by name", &RepoNameRule{Prefix: "x-", Rename: map[string]string{"repo": "other"}}, "org/repo", "other", false},
+		{"rename by full name", &RepoNameRule{Rename: map[string]string{"org/repo": "other"}}, "org/repo", "other", false},
+		{"unknown field", &RepoNameRule{Template: "{{.Group}}"}, "org/repo", "", true},
+		{"invalid full name", &RepoNameRule{}, "repo", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.rule.TargetName(tt.fullName, "")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TargetName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("TargetName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRepoNameRuleUsesOwner(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     *RepoNameRule
+		fullName string
+		want     bool
+	}{
+		{"nil rule", nil, "org/child/repo", false},
+		{"no template", &RepoNameRule{Prefix: "x-"}, "org/child/repo", false},
+		{"name only", &RepoNameRule{Template: "mirror-{{.Name}}"}, "org/child/repo", false},
+		{"owner", &RepoNameRule{Template: "{{.Owner}}-{{.Name}}"}, "org/child/repo", true},
+		{"full name", &RepoNameRule{Template: "{{ .FullName }}"}, "org/child/repo", true},
+		{"renamed", &RepoNameRule{Template: "{{.Owner}}-{{.Name}}", Rename: map[string]string{"repo": "other"}}, "org/child/repo", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.UsesOwner(tt.fullName, ""); got != tt.want {
+				t.Errorf("UsesOwner() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRepoRenames(t *testing.T) {
+	got, err := ParseRepoRenames([]string{"api=platform-api", " org/web = portal "})
+	if err != nil {
+		t.Fatalf("ParseRepoRenames() error = %v", err)
+	}
+	want := map[string]string{"api": "platform-api", "org/web": "portal"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseRepoRenames() = %v, want %v", got, want)
+	}
+	for _, bad := range []string{"api", "=x", "x="} {
+		if _, err := ParseRepoRenames([]string{bad}); err == nil {
+			t.Errorf("ParseRepoRenames(%q) expected error", bad)
+		}
+	}
+}
+
+func TestRepoNameCollisions(t *testing.T) {
+	got := RepoNameCollisions(map[string]string{
+		"a/api": "api",
+		"b/api": "API",
+		"a/web": "web",
+	})
+	want := map[string][]string{"api": {"a/api", "b/api"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("RepoNameCollisions() = %v, want %v", got, want)
+	}
+}
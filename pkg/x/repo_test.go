@@ -151,3 +151,69 @@ func TestRepoFullNameOrgName(t *testing.T) {
 		}
 	}
 }
+
+func TestRepoSubPath(t *testing.T) {
+	tests := []struct {
+		rootOrg  string
+		fullName string
+		want     string
+	}{
+		{"org", "org/repo", ""},
+		{"org", "org/child/repo", "child"},
+		{"org/", "org/child/child2/repo", "child/child2"},
+		{"org", "other/repo", ""},
+		{"org", "org2/child/repo", ""},
+		{"", "org/repo", ""},
+	}
+
+	for _, tt := range tests {
+		got := RepoSubPath(tt.rootOrg, tt.fullName)
+		if got != tt.want {
+			t.Errorf("RepoSubPath(%q, %q) = %q; want %q", tt.rootOrg, tt.fullName, got, tt.want)
+		}
+	}
+}
+
+func TestRepoWikiURL(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"https://github.com/org/repo.git", "https://github.com/org/repo.wiki.git", false},
+		{"https://gitee.com/org/repo", "https://gitee.com/org/repo.wiki.git", false},
+		{"https://cnb.cool/org/child/repo/", "https://cnb.cool/org/child/repo.wiki.git", false},
+		{"https://github.com/org/repo.wiki.git", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := RepoWikiURL(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("RepoWikiURL(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("RepoWikiURL(%q) = %q; want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestRepoMatch(t *testing.T) {
+	tests := []struct {
+		fullName string
+		patterns []string
+		want     bool
+	}{
+		{"org/api-server", nil, true},
+		{"org/api-server", []string{"api-*"}, true},
+		{"org/api-server", []string{"org/*"}, true},
+		{"org/child/api", []string{"org/*"}, false},
+		{"org/child/api", []string{"org/*/*"}, true},
+		{"org/web", []string{"api-*", "other/*"}, false},
+	}
+
+	for _, tt := range tests {
+		if got := RepoMatch(tt.fullName, tt.patterns); got != tt.want {
+			t.Errorf("RepoMatch(%q, %v) = %v; want %v", tt.fullName, tt.patterns, got, tt.want)
+		}
+	}
+}